@@ -0,0 +1,239 @@
+package rx
+
+import (
+	"sync"
+	"time"
+)
+
+// BufferCount operator, emits []interface{} batches of count events
+func (id *Observable) BufferCount(count int) *Observable {
+	log.Println(id.UID, "Observable.BufferCount")
+	return id.bufferWith(count, 0)
+}
+
+// BufferTime operator, emits []interface{} batches of the events received
+// within d of the first event of the batch, empty batches are not emitted
+func (id *Observable) BufferTime(d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.BufferTime")
+	return id.bufferWith(0, d)
+}
+
+// BufferWithCountOrTime operator, emits a batch on count events or once d has
+// elapsed since the first event of the batch, whichever comes first
+func (id *Observable) BufferWithCountOrTime(count int, d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.BufferWithCountOrTime")
+	return id.bufferWith(count, d)
+}
+
+// bufferWith helper, a partial batch is flushed on completion and dropped on error
+func (id *Observable) bufferWith(count int, d time.Duration) *Observable {
+	index := len(id.nextOps)
+	batch := []interface{}{}
+	generation := 0
+	var timer Timer
+
+	flush := func() []interface{} {
+		events := batch
+		batch = []interface{}{}
+		generation++
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		return events
+	}
+
+	id.Map(func(event interface{}) interface{} {
+		batch = append(batch, event)
+		if count > 0 && len(batch) >= count {
+			return flush()
+		}
+		if d > 0 && timer == nil {
+			current := generation
+			timer = id.after(d, func() bool {
+				// stale timer, the batch was already flushed by count
+				if current != generation {
+					return true
+				}
+				return id.onNextFrom(flush(), index+1)
+			})
+		}
+		return nil
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		if len(batch) == 0 {
+			return index + 1, nil
+		}
+		return index + 1, []interface{}{flush()}
+	}})
+
+	return id
+}
+
+// WindowCount operator, emits a nested *Observable for each count events
+func (id *Observable) WindowCount(count int) *Observable {
+	log.Println(id.UID, "Observable.WindowCount")
+	return id.windowWith(count, 0)
+}
+
+// WindowTime operator, emits a nested *Observable for the events received
+// within d of the first event of the window
+func (id *Observable) WindowTime(d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.WindowTime")
+	return id.windowWith(0, d)
+}
+
+// windowWith helper, windows open on their first event, complete with the
+// source and are errored with the source
+func (id *Observable) windowWith(count int, d time.Duration) *Observable {
+	index := len(id.nextOps)
+	var current *window
+	events := 0
+	generation := 0
+	var timer Timer
+
+	closeWindow := func(event Event) {
+		if current == nil {
+			return
+		}
+		if event.Type == EventTypeComplete {
+			event.Complete = current.Observable
+		}
+		current.push(event)
+		current = nil
+		events = 0
+		generation++
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+	}
+
+	id.Map(func(event interface{}) interface{} {
+		var opened interface{}
+		if current == nil {
+			current = newWindow(id)
+			opened = current.Observable
+			if d > 0 {
+				active := generation
+				timer = id.after(d, func() bool {
+					if active == generation {
+						closeWindow(Event{Type: EventTypeComplete})
+					}
+					return true
+				})
+			}
+		}
+		current.push(Event{Type: EventTypeNext, Next: event})
+		events++
+		if count > 0 && events >= count {
+			closeWindow(Event{Type: EventTypeComplete})
+		}
+		// nil when the event went to an already open window
+		return opened
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		closeWindow(Event{Type: EventTypeComplete})
+		return index + 1, nil
+	}}, operator{operatorError, func(err error) {
+		closeWindow(Event{Type: EventTypeError, Error: err})
+	}})
+
+	return id
+}
+
+//
+// window is a unicast Observable that buffers its events until subscribed,
+// so events are not lost while the consumer subscribes to the new window. A
+// window still unsubscribed once its parent finalizes is discarded.
+//
+
+type window struct {
+	*Observable
+	queueMutex sync.Mutex
+	queue      []Event
+	signal     chan bool
+}
+
+func newWindow(parent *Observable) *window {
+	id := &window{
+		Observable: NewObservable(),
+		queue:      []Event{},
+		signal:     make(chan bool, 1),
+	}
+	id.UID = "window." + id.UID
+	id.source = true
+	id.setScheduler(parent.scheduler)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		wg.Done()
+		// wait for connect
+		select {
+		case <-id.connect:
+			break
+		case <-parent.ctx.Done():
+			id.discard()
+			select {
+			case <-id.connect:
+				break
+			case <-id.ctx.Done():
+				return
+			}
+			break
+		case <-id.ctx.Done():
+			return
+		}
+
+		for {
+			id.queueMutex.Lock()
+			events := id.queue
+			id.queue = []Event{}
+			id.queueMutex.Unlock()
+			for _, event := range events {
+				if !id.emit(event) || event.Type != EventTypeNext {
+					return
+				}
+			}
+			// releases the connect or the signal that woke the drain
+			id.pending.done()
+			select {
+			case <-id.signal:
+				break
+			case <-id.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	return id
+}
+
+func (id *window) push(event Event) {
+	id.queueMutex.Lock()
+	id.queue = append(id.queue, event)
+	id.queueMutex.Unlock()
+	id.pending.add()
+	select {
+	case id.signal <- true:
+		break
+	default:
+		id.pending.done()
+		break
+	}
+}
+
+// discard helper, finalizes the window unless it has been subscribed. Runs
+// the check as a task so that a subscription sent ahead of it is seen.
+func (id *window) discard() {
+	id.post(func() bool {
+		id.observersMutex.RLock()
+		defer id.observersMutex.RUnlock()
+		return len(id.observers) != 0
+	})
+}
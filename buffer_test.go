@@ -0,0 +1,45 @@
+package rx
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWindowCount(t *testing.T) {
+	expect := "[[1 2] [3 4] [5]]"
+
+	actual := [][]interface{}{}
+	values := []interface{}{1, 2, 3, 4, 5}
+	sub := NewFrom(values).WindowCount(2).ConcatMap(func(next interface{}) *Observable {
+		return next.(*Observable).Reduce([]interface{}{}, func(acc interface{}, event interface{}) interface{} {
+			return append(acc.([]interface{}), event)
+		})
+	}).SubscribeFunc(func(next interface{}) {
+		actual = append(actual, next.([]interface{}))
+	}, nil, nil)
+	<-sub.Done()
+
+	if fmt.Sprint(actual) != expect {
+		t.Fatalf("Expected windows %v but got %v", expect, actual)
+	}
+}
+
+func TestWindowDiscard(t *testing.T) {
+	windows := []*Observable{}
+	values := []interface{}{1, 2, 3}
+	sub := NewFrom(values).WindowCount(2).SubscribeFunc(func(next interface{}) {
+		windows = append(windows, next.(*Observable))
+	}, nil, nil)
+	<-sub.Done()
+
+	// windows nobody subscribed to do not outlive the source
+	for _, window := range windows {
+		select {
+		case <-window.Finalize:
+			break
+		case <-time.After(1 * time.Second):
+			t.Fatalf("Expected Finalize of an unsubscribed window")
+		}
+	}
+}
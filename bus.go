@@ -0,0 +1,340 @@
+package rx
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Message type, a value published to an EventBus topic
+type Message struct {
+	Topic string
+	Value interface{}
+	seq   uint64
+}
+
+// EventBus type, routes the values published to dot separated topics to the
+// Observables observing a matching pattern
+type EventBus struct {
+	dropped   uint64 // atomic, first for 64-bit alignment
+	mutex     sync.RWMutex
+	seq       uint64
+	subjects  map[*Observable]*busSubject
+	retained  map[string]*Message
+	expiries  map[string]Timer
+	expiry    time.Duration
+	scheduler Scheduler
+	strategy  BackpressureStrategy
+	queueSize int
+}
+
+// busSubject type, an observed Subject with its own bounded delivery queue so
+// that a slow subscriber never blocks Publish
+type busSubject struct {
+	*Observable
+	bus        *EventBus
+	pattern    []string
+	delivered  uint64
+	queueMutex sync.Mutex
+	queue      []*Message
+	overflowed bool
+	signal     chan bool
+}
+
+// NewEventBus init, a retained value no Observable matches is dropped after
+// a minute and each Observable queues up to 1024 undelivered messages,
+// dropping the oldest once full
+func NewEventBus() *EventBus {
+	log.Println("EventBus.NewEventBus")
+	id := &EventBus{
+		subjects:  map[*Observable]*busSubject{},
+		retained:  map[string]*Message{},
+		expiries:  map[string]Timer{},
+		expiry:    1 * time.Minute,
+		scheduler: DefaultScheduler,
+		strategy:  BackpressureDropOldest,
+		queueSize: 1024,
+	}
+	return id
+}
+
+// WithExpiry modifier, replaces the wait ahead of dropping a retained value
+// no Observable matches, 0 keeps retained values until cleared. Must be
+// applied before publishing.
+func (id *EventBus) WithExpiry(expiry time.Duration) *EventBus {
+	log.Println("EventBus.WithExpiry", expiry)
+	id.expiry = expiry
+	return id
+}
+
+// WithBackpressure modifier, bounds the delivery queue of each Observable to
+// queueSize messages and applies strategy once it is full, BackpressureBuffer
+// errors the Observable with ErrBufferOverflow. Publish never waits on a
+// subscriber, so BackpressureBlock drops the oldest message. Must be applied
+// before observing.
+func (id *EventBus) WithBackpressure(strategy BackpressureStrategy, queueSize int) *EventBus {
+	log.Println("EventBus.WithBackpressure", strategy, queueSize)
+	if strategy == BackpressureBlock {
+		strategy = BackpressureDropOldest
+	}
+	if strategy == BackpressureKeepLatest || queueSize < 1 {
+		queueSize = 1
+	}
+	id.strategy = strategy
+	id.queueSize = queueSize
+	return id
+}
+
+// Dropped returns the count of messages dropped from full delivery queues
+func (id *EventBus) Dropped() uint64 {
+	return atomic.LoadUint64(&id.dropped)
+}
+
+// WithScheduler modifier, replaces the Scheduler timing the expiry of
+// retained values, must be applied before publishing
+func (id *EventBus) WithScheduler(scheduler Scheduler) *EventBus {
+	log.Println("EventBus.WithScheduler")
+	id.scheduler = scheduler
+	return id
+}
+
+// Publish sends a *Message to every Observable with a pattern matching topic
+// and retains value as the last value of topic, publishing nil clears it.
+// Delivery is queued per Observable and Publish never waits on a subscriber.
+func (id *EventBus) Publish(topic string, value interface{}) {
+	log.Println("EventBus.Publish", topic)
+	tokens := splitTopic(topic)
+
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	if value == nil {
+		delete(id.retained, topic)
+		if timer, ok := id.expiries[topic]; ok {
+			timer.Stop()
+			delete(id.expiries, topic)
+		}
+		return
+	}
+
+	id.seq++
+	message := &Message{Topic: topic, Value: value, seq: id.seq}
+	id.retained[topic] = message
+	matched := false
+	for _, subject := range id.subjects {
+		if matchTopic(subject.pattern, tokens) {
+			matched = true
+			subject.push(message)
+		}
+	}
+	if !matched {
+		id.expire(topic)
+	}
+}
+
+// Observe returns a Subject of the *Message published to topics matching
+// pattern, each subscriber first receives the retained values of the
+// matching topics. In a pattern '*' matches one topic token and a trailing
+// '>' matches one or more. The Subject is routed by the EventBus from its
+// first subscriber until its last observer unsubscribes.
+func (id *EventBus) Observe(pattern string) *Observable {
+	log.Println("EventBus.Observe", pattern)
+	subject := &busSubject{
+		Observable: NewSubject(),
+		bus:        id,
+		pattern:    splitTopic(pattern),
+		queue:      []*Message{},
+		signal:     make(chan bool, 1),
+	}
+	subject.UID = "bus." + subject.UID
+
+	subject.Tap(func(event interface{}) {
+		if message, ok := event.(*Message); ok {
+			subject.delivered = message.seq
+		}
+	})
+
+	registered := false
+	subject.StartWith(func() []interface{} {
+		id.mutex.Lock()
+		defer id.mutex.Unlock()
+		if !registered {
+			registered = true
+			subject.delivered = id.seq
+			id.subjects[subject.Observable] = subject
+			go id.drain(subject)
+		}
+		// later values are queued and reach the subscriber as they are delivered
+		return id.matchRetained(subject.pattern, subject.delivered)
+	})
+
+	return subject.Observable
+}
+
+// Retained returns the retained value of topic
+func (id *EventBus) Retained(topic string) (interface{}, bool) {
+	id.mutex.RLock()
+	defer id.mutex.RUnlock()
+	message, ok := id.retained[topic]
+	if !ok {
+		return nil, false
+	}
+	return message.Value, true
+}
+
+// drain helper, delivers the queued messages of subject and removes it from
+// the EventBus once it finalizes or its queue overflowed
+func (id *EventBus) drain(subject *busSubject) {
+	defer id.remove(subject)
+	for {
+		subject.queueMutex.Lock()
+		messages := subject.queue
+		overflowed := subject.overflowed
+		subject.queue = []*Message{}
+		subject.queueMutex.Unlock()
+		for _, message := range messages {
+			if !subject.emit(Event{Type: EventTypeNext, Next: message}) {
+				return
+			}
+		}
+		if overflowed {
+			subject.emit(Event{Type: EventTypeError, Error: ErrBufferOverflow})
+			return
+		}
+		select {
+		case <-subject.signal:
+			break
+		case <-subject.ctx.Done():
+			return
+		}
+	}
+}
+
+// remove helper, stops routing to subject and expires the retained values
+// no other Observable matches
+func (id *EventBus) remove(subject *busSubject) {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	delete(id.subjects, subject.Observable)
+	for topic := range id.retained {
+		tokens := splitTopic(topic)
+		if matchTopic(subject.pattern, tokens) && !id.matched(tokens) {
+			id.expire(topic)
+		}
+	}
+}
+
+// expire helper, drops the retained value of topic once expiry has elapsed
+// unless it was replaced or became matched, called with the mutex held
+func (id *EventBus) expire(topic string) {
+	if id.expiry <= 0 {
+		return
+	}
+	if timer, ok := id.expiries[topic]; ok {
+		timer.Stop()
+	}
+	message := id.retained[topic]
+	var timer Timer
+	timer = id.scheduler.AfterFunc(id.expiry, func() {
+		id.mutex.Lock()
+		defer id.mutex.Unlock()
+		if id.expiries[topic] != timer {
+			return
+		}
+		delete(id.expiries, topic)
+		if id.retained[topic] == message && !id.matched(splitTopic(topic)) {
+			log.Println("EventBus.expire", topic)
+			delete(id.retained, topic)
+		}
+	})
+	id.expiries[topic] = timer
+}
+
+// matched helper, true if an Observable matches topic, called with the mutex held
+func (id *EventBus) matched(topic []string) bool {
+	for _, subject := range id.subjects {
+		if matchTopic(subject.pattern, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRetained helper, the retained messages matching pattern published up
+// to seq ordered by topic, called with the mutex held
+func (id *EventBus) matchRetained(pattern []string, seq uint64) []interface{} {
+	topics := []string{}
+	for topic, message := range id.retained {
+		if message.seq <= seq && matchTopic(pattern, splitTopic(topic)) {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+
+	messages := make([]interface{}, 0, len(topics))
+	for _, topic := range topics {
+		messages = append(messages, id.retained[topic])
+	}
+	return messages
+}
+
+// push helper, queues message for delivery without blocking and applies the
+// backpressure strategy of the EventBus once the queue is full
+func (id *busSubject) push(message *Message) {
+	id.queueMutex.Lock()
+	switch {
+	case id.overflowed:
+		atomic.AddUint64(&id.bus.dropped, 1)
+		break
+	case len(id.queue) < id.bus.queueSize:
+		id.queue = append(id.queue, message)
+		break
+	case id.bus.strategy == BackpressureDropNewest:
+		log.Println(id.UID, "busSubject.push dropped", message.Topic)
+		atomic.AddUint64(&id.bus.dropped, 1)
+		break
+	case id.bus.strategy == BackpressureBuffer:
+		log.Println(id.UID, "busSubject.push overflowed", message.Topic)
+		atomic.AddUint64(&id.bus.dropped, 1)
+		id.overflowed = true
+		break
+	default:
+		log.Println(id.UID, "busSubject.push dropped", id.queue[0].Topic)
+		atomic.AddUint64(&id.bus.dropped, 1)
+		id.queue = append(id.queue[1:], message)
+		break
+	}
+	id.queueMutex.Unlock()
+	select {
+	case id.signal <- true:
+		break
+	default:
+		break
+	}
+}
+
+// matchTopic helper
+func matchTopic(pattern []string, topic []string) bool {
+	for i, token := range pattern {
+		if token == ">" && i == len(pattern)-1 {
+			return len(topic) > i
+		}
+		if i >= len(topic) || (token != "*" && token != topic[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(topic)
+}
+
+// splitTopic helper
+func splitTopic(topic string) []string {
+	return strings.Split(topic, ".")
+}
+
+// observers helper, the count of Observables routed by the EventBus
+func (id *EventBus) observers() int {
+	id.mutex.RLock()
+	defer id.mutex.RUnlock()
+	return len(id.subjects)
+}
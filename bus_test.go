@@ -0,0 +1,259 @@
+package rx
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestMatchTopic(t *testing.T) {
+	cases := []struct {
+		pattern string
+		topic   string
+		match   bool
+	}{
+		{"sensors.a.temp", "sensors.a.temp", true},
+		{"sensors.a.temp", "sensors.b.temp", false},
+		{"sensors.*.temp", "sensors.b.temp", true},
+		{"sensors.*.temp", "sensors.b.humidity", false},
+		{"sensors.*.temp", "sensors.b.c.temp", false},
+		{"sensors.>", "sensors.b.temp", true},
+		{"sensors.>", "sensors", false},
+		{"sensors.*", "sensors", false},
+		{">", "sensors", true},
+		{"sensors.>.temp", "sensors.b.temp", false},
+	}
+	for _, c := range cases {
+		if match := matchTopic(splitTopic(c.pattern), splitTopic(c.topic)); match != c.match {
+			t.Errorf("Expected %q match of %q to be %v but got %v", c.pattern, c.topic, c.match, match)
+		}
+	}
+}
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	bus.Publish("sensors.a.temp", 20)
+	bus.Publish("sensors.b.humidity", 50)
+
+	received := make(chan *Message, 10)
+	sub := bus.Observe("sensors.*.temp").SubscribeFunc(func(next interface{}) {
+		received <- next.(*Message)
+	}, nil, nil)
+
+	expect := func(topic string, value interface{}) {
+		select {
+		case message := <-received:
+			if message.Topic != topic || message.Value != value {
+				t.Fatalf("Expected %v %v but got %v %v", topic, value, message.Topic, message.Value)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %v %v but got none", topic, value)
+		}
+	}
+
+	// retained value of the matching topic
+	expect("sensors.a.temp", 20)
+
+	bus.Publish("sensors.b.humidity", 51)
+	bus.Publish("sensors.b.temp", 21)
+	expect("sensors.b.temp", 21)
+
+	if value, ok := bus.Retained("sensors.b.temp"); !ok || value != 21 {
+		t.Fatalf("Expected retained value %v but got %v", 21, value)
+	}
+	bus.Publish("sensors.b.temp", nil)
+	if _, ok := bus.Retained("sensors.b.temp"); ok {
+		t.Fatalf("Expected retained value to be cleared")
+	}
+
+	sub.Unsubscribe()
+	for i := 0; bus.observers() != 0; i++ {
+		if i == 1000 {
+			t.Fatalf("Expected observer count of %v but got %v", 0, bus.observers())
+		}
+		runtime.Gosched()
+		<-time.After(time.Millisecond)
+	}
+}
+
+func TestEventBusCleanup(t *testing.T) {
+	scheduler := NewTestScheduler()
+	bus := NewEventBus().WithScheduler(scheduler).WithExpiry(time.Second)
+
+	// an Observable nobody subscribes to is never routed
+	bus.Observe("sensors.>")
+	if bus.observers() != 0 {
+		t.Fatalf("Expected observer count of %v but got %v", 0, bus.observers())
+	}
+
+	// a retained value nobody observes expires
+	bus.Publish("sensors.a.temp", 20)
+	scheduler.Advance(time.Second)
+	if _, ok := bus.Retained("sensors.a.temp"); ok {
+		t.Fatalf("Expected unobserved retained value to expire")
+	}
+
+	received := make(chan interface{}, 1)
+	sub := bus.Observe("sensors.>").SubscribeFunc(func(next interface{}) {
+		received <- next.(*Message).Value
+	}, nil, nil)
+	bus.Publish("sensors.b.temp", 21)
+	<-received
+	scheduler.Advance(2 * time.Second)
+	if _, ok := bus.Retained("sensors.b.temp"); !ok {
+		t.Fatalf("Expected observed retained value to be kept")
+	}
+
+	// an observed value expires once its last observer is gone
+	sub.Unsubscribe()
+	for i := 0; bus.observers() != 0; i++ {
+		if i == 1000 {
+			t.Fatalf("Expected observer count of %v but got %v", 0, bus.observers())
+		}
+		runtime.Gosched()
+		<-time.After(time.Millisecond)
+	}
+	scheduler.Advance(time.Second)
+	if _, ok := bus.Retained("sensors.b.temp"); ok {
+		t.Fatalf("Expected retained value to expire after the last unsubscribe")
+	}
+}
+
+func TestEventBusSlowSubscriber(t *testing.T) {
+	bus := NewEventBus()
+	blocked := make(chan bool)
+	slow := bus.Observe("slow").SubscribeFunc(func(next interface{}) {
+		<-blocked
+	}, nil, nil)
+	defer close(blocked)
+	defer slow.Unsubscribe()
+
+	received := make(chan interface{}, 1)
+	fast := bus.Observe("fast").SubscribeFunc(func(next interface{}) {
+		received <- next.(*Message).Value
+	}, nil, nil)
+	defer fast.Unsubscribe()
+
+	// the blocked subscriber stalls neither Publish nor the other subscribers
+	for i := 0; i < 100; i++ {
+		bus.Publish("slow", i)
+	}
+	bus.Publish("fast", 1)
+	select {
+	case <-received:
+		break
+	case <-time.After(time.Second):
+		t.Fatalf("Expected delivery past a blocked subscriber")
+	}
+}
+
+func TestEventBusBackpressure(t *testing.T) {
+	bus := NewEventBus().WithBackpressure(BackpressureDropOldest, 2)
+	blocked := make(chan bool)
+	received := make(chan int, 100)
+	sub := bus.Observe("slow").SubscribeFunc(func(next interface{}) {
+		<-blocked
+		received <- next.(*Message).Value.(int)
+	}, nil, nil)
+	defer sub.Unsubscribe()
+
+	for bus.observers() == 0 {
+		<-time.After(time.Millisecond)
+	}
+
+	// the queue of the blocked subscriber keeps the latest values only
+	for i := 1; i <= 100; i++ {
+		bus.Publish("slow", i)
+	}
+	if bus.Dropped() == 0 {
+		t.Fatalf("Expected dropped messages past the queue size")
+	}
+	close(blocked)
+
+	count := 0
+	for last := 0; last != 100; count++ {
+		select {
+		case value := <-received:
+			if value <= last {
+				t.Fatalf("Expected values after %v but got %v", last, value)
+			}
+			last = value
+		case <-time.After(time.Second):
+			t.Fatalf("Expected value %v but got none after %v", 100, last)
+		}
+	}
+	if dropped := bus.Dropped(); uint64(count)+dropped != 100 {
+		t.Fatalf("Expected %v delivered and dropped but got %v and %v", 100, count, dropped)
+	}
+}
+
+func TestEventBusBackpressureBuffer(t *testing.T) {
+	bus := NewEventBus().WithBackpressure(BackpressureBuffer, 2)
+	blocked := make(chan bool)
+	failed := make(chan error, 1)
+	bus.Observe("slow").SubscribeFunc(func(next interface{}) {
+		<-blocked
+	}, func(err error) {
+		failed <- err
+	}, nil)
+
+	for bus.observers() == 0 {
+		<-time.After(time.Millisecond)
+	}
+
+	// an overflowed queue errors the subscriber and is no longer routed
+	for i := 1; i <= 100; i++ {
+		bus.Publish("slow", i)
+	}
+	close(blocked)
+	select {
+	case err := <-failed:
+		if err != ErrBufferOverflow {
+			t.Fatalf("Expected error %v but got %v", ErrBufferOverflow, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected error %v but got none", ErrBufferOverflow)
+	}
+	for i := 0; bus.observers() != 0; i++ {
+		if i == 1000 {
+			t.Fatalf("Expected observer count of %v but got %v", 0, bus.observers())
+		}
+		<-time.After(time.Millisecond)
+	}
+}
+
+func TestEventBusRetainedOnce(t *testing.T) {
+	bus := NewEventBus()
+	subject := bus.Observe("counter")
+	first := subject.SubscribeFunc(func(next interface{}) {}, nil, nil)
+	defer first.Unsubscribe()
+
+	done := make(chan bool)
+	go func() {
+		for i := 1; i <= 1000; i++ {
+			bus.Publish("counter", i)
+		}
+		close(done)
+	}()
+
+	// a subscriber joining while values are published sees each value once
+	values := make(chan int, 1001)
+	second := subject.SubscribeFunc(func(next interface{}) {
+		values <- next.(*Message).Value.(int)
+	}, nil, nil)
+	defer second.Unsubscribe()
+	<-done
+
+	last := 0
+	for last != 1000 {
+		select {
+		case value := <-values:
+			if value <= last {
+				t.Fatalf("Expected values after %v but got %v", last, value)
+			}
+			last = value
+		case <-time.After(time.Second):
+			t.Fatalf("Expected value %v but got none after %v", 1000, last)
+		}
+	}
+}
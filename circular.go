@@ -1,11 +1,13 @@
 package rx
 
-// Not thread safe *but* is used in a single goroutine
-// which avoids async writes so should be ok
+import "sync"
+
+// Written from a single goroutine, reads are guarded so the values can be
+// queried synchronously from other goroutines
 
 // CircularBuffer type
 type CircularBuffer struct {
-	// lock     sync.RWMutex
+	lock     sync.RWMutex
 	buffer   []interface{}
 	start    int
 	end      int
@@ -27,8 +29,8 @@ func NewCircularBuffer(capacity int) *CircularBuffer {
 
 // Add a value to the buffer (looks good)
 func (id *CircularBuffer) Add(value interface{}) {
-	// defer id.lock.Unlock()
-	// id.lock.Lock()
+	defer id.lock.Unlock()
+	id.lock.Lock()
 
 	id.end++
 	// loop check
@@ -55,8 +57,8 @@ func (id *CircularBuffer) Add(value interface{}) {
 
 // Next iterate the values
 func (id *CircularBuffer) Next(next int) (int, interface{}) {
-	// defer id.lock.RUnlock()
-	// id.lock.RLock()
+	defer id.lock.RUnlock()
+	id.lock.RLock()
 
 	index := next
 
@@ -83,3 +85,65 @@ func (id *CircularBuffer) Next(next int) (int, interface{}) {
 	// return next index and current value
 	return index, value
 }
+
+// First returns the oldest value
+func (id *CircularBuffer) First() (interface{}, bool) {
+	defer id.lock.RUnlock()
+	id.lock.RLock()
+
+	if id.Length == 0 {
+		return nil, false
+	}
+	return id.buffer[id.start], true
+}
+
+// Shift removes and returns the oldest value
+func (id *CircularBuffer) Shift() (interface{}, bool) {
+	defer id.lock.Unlock()
+	id.lock.Lock()
+
+	if id.Length == 0 {
+		return nil, false
+	}
+	value := id.buffer[id.start]
+	id.buffer[id.start] = nil
+	id.Length--
+	if id.Length == 0 {
+		id.start = 0
+		id.end = -1
+		return value, true
+	}
+	id.start++
+	if id.start >= id.Capacity {
+		id.start = 0
+	}
+	return value, true
+}
+
+// Last returns the most recently added value
+func (id *CircularBuffer) Last() (interface{}, bool) {
+	defer id.lock.RUnlock()
+	id.lock.RLock()
+
+	if id.Length == 0 {
+		return nil, false
+	}
+	return id.buffer[id.end], true
+}
+
+// Values returns a copy of the values from oldest to newest
+func (id *CircularBuffer) Values() []interface{} {
+	defer id.lock.RUnlock()
+	id.lock.RLock()
+
+	values := make([]interface{}, 0, id.Length)
+	index := id.start
+	for i := 0; i < id.Length; i++ {
+		values = append(values, id.buffer[index])
+		index++
+		if index >= id.Capacity {
+			index = 0
+		}
+	}
+	return values
+}
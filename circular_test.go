@@ -64,3 +64,50 @@ func TestCircularLoop(t *testing.T) {
 		t.Fatalf("Expected Length %v but got %v", capacity, buffer.Capacity)
 	}
 }
+
+func TestCircularValues(t *testing.T) {
+	buffer := NewCircularBuffer(3)
+	if _, ok := buffer.Last(); ok {
+		t.Fatalf("Expected no last value for an empty buffer")
+	}
+	for i := 1; i <= 4; i++ {
+		buffer.Add(i)
+	}
+
+	values := buffer.Values()
+	expected := []interface{}{2, 3, 4}
+	if len(values) != len(expected) {
+		t.Fatalf("Expected values %v but got %v", expected, values)
+	}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Fatalf("Expected values %v but got %v", expected, values)
+		}
+	}
+	if last, ok := buffer.Last(); !ok || last != 4 {
+		t.Fatalf("Expected last value %v but got %v", 4, last)
+	}
+}
+
+func TestCircularShift(t *testing.T) {
+	buffer := NewCircularBuffer(3)
+	for i := 1; i <= 4; i++ {
+		buffer.Add(i)
+	}
+
+	if value, ok := buffer.Shift(); !ok || value != 2 {
+		t.Fatalf("Expected shifted value %v but got %v", 2, value)
+	}
+	buffer.Add(5)
+	buffer.Add(6)
+	values := buffer.Values()
+	if len(values) != 3 || values[0] != 4 || values[2] != 6 {
+		t.Fatalf("Expected values %v but got %v", []int{4, 5, 6}, values)
+	}
+	for buffer.Length != 0 {
+		buffer.Shift()
+	}
+	if _, ok := buffer.Shift(); ok {
+		t.Fatalf("Expected no value from an empty buffer")
+	}
+}
@@ -52,11 +52,9 @@ func demoRetry(observer *rx.Observer) *rx.Observable {
 		return nil
 	}
 	subject.UID = "demoRetryObservable." + subject.UID
-	retry := 2
-	subject.RepeatWhen(func() bool {
-		retry--
-		<-time.After(1 * time.Second)
-		return (retry != 0)
+	subject.RepeatWithPolicy(rx.RetryPolicy{
+		MaxAttempts:    1,
+		InitialBackoff: 1 * time.Second,
 	}).Take(1)
 	return subject
 }
@@ -0,0 +1,264 @@
+package rx
+
+import (
+	"sync"
+)
+
+// combine helper, subscribes to observables once the returned Observable is
+// connected, the callbacks run on the Observable goroutine with the index of
+// the source. When not nil subs receives the subscription of each source.
+// The returned Observable uses the Scheduler of the first source.
+func combine(name string, observables []*Observable, subs []*Observer, onNext func(id *Observable, index int, event interface{}) bool, onComplete func(id *Observable, index int) bool) *Observable {
+	log.Println(name)
+	id := NewObservable()
+	id.source = true
+	if len(observables) != 0 {
+		id.setScheduler(observables[0].scheduler)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		wg.Done()
+		// wait for connect
+		select {
+		case <-id.connect:
+			break
+		case <-id.ctx.Done():
+			return
+		}
+
+		// releases the connect once the work it starts is counted
+		defer id.pending.done()
+		if len(observables) == 0 {
+			id.emit(Event{Type: EventTypeComplete, Complete: id})
+			return
+		}
+		// subscribe on the Observable goroutine so subs is set ahead of the callbacks
+		id.post(func() bool {
+			for i, observable := range observables {
+				index := i
+				sub := id.subscribeInner(observable, func(next interface{}) bool {
+					return onNext(id, index, next)
+				}, func(err error) bool {
+					return id.onError(err)
+				}, func() bool {
+					return onComplete(id, index)
+				})
+				if subs != nil {
+					subs[index] = sub
+				}
+			}
+			return true
+		})
+	}()
+
+	wg.Wait()
+	return id
+}
+
+// CombineLatest init, emits a []interface{} of the latest event of each
+// observable once all have emitted, completes when all have completed
+func CombineLatest(observables ...*Observable) *Observable {
+	latest := make([]interface{}, len(observables))
+	has := make([]bool, len(observables))
+	ready := 0
+	completed := 0
+
+	return combine("Combine.CombineLatest", observables, nil, func(id *Observable, index int, event interface{}) bool {
+		if !has[index] {
+			has[index] = true
+			ready++
+		}
+		latest[index] = event
+		if ready != len(observables) {
+			return true
+		}
+		values := make([]interface{}, len(latest))
+		copy(values, latest)
+		return id.onNext(values)
+	}, func(id *Observable, index int) bool {
+		completed++
+		// a source completing without an event can never be combined
+		if completed == len(observables) || !has[index] {
+			return id.onComplete(id)
+		}
+		return true
+	})
+}
+
+// Zip init, emits a []interface{} pairing the nth event of each observable,
+// completes once a completed observable has no buffered events remaining
+func Zip(observables ...*Observable) *Observable {
+	queues := make([][]interface{}, len(observables))
+	completed := make([]bool, len(observables))
+
+	drained := func() bool {
+		for i := range queues {
+			if completed[i] && len(queues[i]) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	return combine("Combine.Zip", observables, nil, func(id *Observable, index int, event interface{}) bool {
+		queues[index] = append(queues[index], event)
+		for _, queue := range queues {
+			if len(queue) == 0 {
+				return true
+			}
+		}
+		values := make([]interface{}, len(queues))
+		for i := range queues {
+			values[i] = queues[i][0]
+			queues[i] = queues[i][1:]
+		}
+		if !id.onNext(values) {
+			return false
+		}
+		if drained() {
+			return id.onComplete(id)
+		}
+		return true
+	}, func(id *Observable, index int) bool {
+		completed[index] = true
+		if drained() {
+			return id.onComplete(id)
+		}
+		return true
+	})
+}
+
+// ForkJoin init, emits a []interface{} of the last event of each observable
+// once all have completed, a source completing without an event completes
+// without emitting
+func ForkJoin(observables ...*Observable) *Observable {
+	last := make([]interface{}, len(observables))
+	has := make([]bool, len(observables))
+	completed := 0
+
+	return combine("Combine.ForkJoin", observables, nil, func(id *Observable, index int, event interface{}) bool {
+		has[index] = true
+		last[index] = event
+		return true
+	}, func(id *Observable, index int) bool {
+		if !has[index] {
+			return id.onComplete(id)
+		}
+		completed++
+		if completed != len(observables) {
+			return true
+		}
+		if !id.onNext(last) {
+			return false
+		}
+		return id.onComplete(id)
+	})
+}
+
+// WithLatestFrom operator, emits a []interface{} of each event paired with
+// the latest event of other, events before other has emitted are dropped
+func (id *Observable) WithLatestFrom(other *Observable) *Observable {
+	log.Println(id.UID, "Observable.WithLatestFrom")
+	var latest interface{}
+	has := false
+
+	id.subscribeInner(other, func(next interface{}) bool {
+		latest = next
+		has = true
+		return true
+	}, func(err error) bool {
+		return id.onError(err)
+	}, func() bool {
+		return true
+	})
+
+	id.Filter(func(event interface{}) bool {
+		return has
+	}).Map(func(event interface{}) interface{} {
+		return []interface{}{event, latest}
+	})
+
+	return id
+}
+
+// Concat init, mirrors each observable in turn subscribing to the next only
+// once the previous has completed, uses the Scheduler of the first observable
+func Concat(observables ...*Observable) *Observable {
+	log.Println("Combine.Concat")
+	id := NewObservable()
+	id.source = true
+	if len(observables) != 0 {
+		id.setScheduler(observables[0].scheduler)
+	}
+
+	var next func(index int) bool
+	next = func(index int) bool {
+		if index == len(observables) {
+			return id.onComplete(id)
+		}
+		id.subscribeInner(observables[index], func(event interface{}) bool {
+			return id.onNext(event)
+		}, func(err error) bool {
+			return id.onError(err)
+		}, func() bool {
+			return next(index + 1)
+		})
+		return true
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		wg.Done()
+		// wait for connect
+		select {
+		case <-id.connect:
+			break
+		case <-id.ctx.Done():
+			return
+		}
+
+		id.post(func() bool {
+			return next(0)
+		})
+		id.pending.done()
+	}()
+
+	wg.Wait()
+	return id
+}
+
+// Race init, mirrors the first observable to emit any event and unsubscribes
+// from the others
+func Race(observables ...*Observable) *Observable {
+	winner := -1
+	subs := make([]*Observer, len(observables))
+
+	race := func(id *Observable, index int) bool {
+		if winner == -1 {
+			winner = index
+			for i, sub := range subs {
+				if i != index {
+					id.unsubscribeInner(sub)
+				}
+			}
+		}
+		return winner == index
+	}
+
+	return combine("Combine.Race", observables, subs, func(id *Observable, index int, event interface{}) bool {
+		if !race(id, index) {
+			return true
+		}
+		return id.onNext(event)
+	}, func(id *Observable, index int) bool {
+		if !race(id, index) {
+			return true
+		}
+		return id.onComplete(id)
+	})
+}
@@ -1,36 +1,54 @@
 package rx
 
 import (
+	"context"
 	"sync"
 	"time"
 )
 
 // NewInterval init
 func NewInterval(msec int) *Observable {
+	return NewIntervalContext(context.Background(), msec)
+}
+
+// NewIntervalContext init
+func NewIntervalContext(ctx context.Context, msec int) *Observable {
 	log.Println("Interval.NewInterval")
-	id := NewObservable()
+	id := NewObservableContext(ctx)
+	id.source = true
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 
 	go func() {
 		wg.Done()
-		ticker := time.NewTicker(time.Duration(msec) * time.Millisecond)
+		// wait for connect
+		select {
+		case <-id.connect:
+			break
+		case <-id.ctx.Done():
+			return
+		}
+
+		// created on connect so WithScheduler can be applied after init
+		ticker := id.scheduler.NewTicker(time.Duration(msec) * time.Millisecond)
 		defer func() {
 			ticker.Stop()
 		}()
-
-		// wait for connect
-		<-id.connect
+		id.pending.done()
 
 		i := 0
 		for {
 			select {
-			case <-ticker.C:
-				id.next(i)
+			case <-ticker.C():
+				ok := id.emit(Event{Type: EventTypeNext, Next: i})
+				ticked(id.scheduler)
+				if !ok {
+					return
+				}
 				i++
 				break
-			case <-id.Finalize:
+			case <-id.ctx.Done():
 				return
 			}
 		}
@@ -42,8 +60,14 @@ func NewInterval(msec int) *Observable {
 
 // NewFrom init
 func NewFrom(values []interface{}) *Observable {
+	return NewFromContext(context.Background(), values)
+}
+
+// NewFromContext init
+func NewFromContext(ctx context.Context, values []interface{}) *Observable {
 	log.Println("Interval.NewFrom")
-	id := NewObservable()
+	id := NewObservableContext(ctx)
+	id.source = true
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -51,13 +75,20 @@ func NewFrom(values []interface{}) *Observable {
 	go func() {
 		wg.Done()
 		// wait for connect
-		<-id.connect
+		select {
+		case <-id.connect:
+			break
+		case <-id.ctx.Done():
+			return
+		}
 
 		for _, val := range values {
-			id.Event <- Event{Type: EventTypeNext, Next: val}
+			if !id.emit(Event{Type: EventTypeNext, Next: val}) {
+				return
+			}
 		}
-		id.Yield()
-		id.Event <- Event{Type: EventTypeComplete, Complete: id}
+		id.emit(Event{Type: EventTypeComplete, Complete: id})
+		id.pending.done()
 	}()
 
 	wg.Wait()
@@ -68,6 +99,7 @@ func NewFrom(values []interface{}) *Observable {
 func NewFromMap(value interface{}) *Observable {
 	log.Println("Interval.NewFrom")
 	id := NewObservable()
+	id.source = true
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -75,13 +107,20 @@ func NewFromMap(value interface{}) *Observable {
 	go func() {
 		wg.Done()
 		// wait for connect
-		<-id.connect
+		select {
+		case <-id.connect:
+			break
+		case <-id.ctx.Done():
+			return
+		}
 
 		for key, val := range value.(map[string]interface{}) {
-			id.Event <- Event{Type: EventTypeNext, Next: map[string]interface{}{key: val}}
+			if !id.emit(Event{Type: EventTypeNext, Next: map[string]interface{}{key: val}}) {
+				return
+			}
 		}
-		id.Yield()
-		id.Event <- Event{Type: EventTypeComplete, Complete: id}
+		id.emit(Event{Type: EventTypeComplete, Complete: id})
+		id.pending.done()
 	}()
 
 	wg.Wait()
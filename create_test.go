@@ -1,6 +1,9 @@
 package rx
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 )
 
@@ -51,3 +54,102 @@ loop:
 		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
 	}
 }
+
+func TestIntervalContext(t *testing.T) {
+	events := 3
+
+	nextCnt := 0
+	errorCnt := 0
+	completeCnt := 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	observer := NewObserver()
+	interval := NewIntervalContext(ctx, 5)
+	interval.Subscribe <- observer
+loop:
+	for {
+		select {
+		case event := <-observer.Event:
+			switch event.Type {
+			case EventTypeNext:
+				nextCnt++
+				if nextCnt == events {
+					cancel()
+				}
+				break
+			case EventTypeError:
+				if event.Error != context.Canceled {
+					t.Fatalf("Expected error %v but got %v", context.Canceled, event.Error)
+				}
+				errorCnt++
+				break loop
+			case EventTypeComplete:
+				completeCnt++
+				break loop
+			}
+		}
+	}
+
+	<-interval.Finalize
+
+	if nextCnt < events {
+		t.Fatalf("Expected next count of at least %v but got %v", events, nextCnt)
+	}
+	if errorCnt != 1 {
+		t.Fatalf("Expected error count of %v but got %v", 1, errorCnt)
+	}
+	if completeCnt != 0 {
+		t.Fatalf("Expected complete count of %v but got %v", 0, completeCnt)
+	}
+}
+
+func TestFromOrdering(t *testing.T) {
+	pipelines := 5000
+	events := 10
+
+	values := make([]interface{}, events)
+	for i := range values {
+		values[i] = i
+	}
+
+	var wg sync.WaitGroup
+	failures := make(chan string, pipelines)
+	for p := 0; p < pipelines; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			observer := NewObserver()
+			from := NewFrom(values)
+			from.Subscribe <- observer
+			next := 0
+			for event := range observer.Event {
+				switch event.Type {
+				case EventTypeNext:
+					if event.Next != next {
+						failures <- fmt.Sprintf("expected next %v but got %v", next, event.Next)
+						return
+					}
+					next++
+					continue
+				case EventTypeError:
+					failures <- fmt.Sprintf("unexpected error %v", event.Error)
+					return
+				case EventTypeComplete:
+					if next != events {
+						failures <- fmt.Sprintf("expected %v events before complete but got %v", events, next)
+					}
+				}
+				break
+			}
+			<-from.Finalize
+		}()
+	}
+	wg.Wait()
+	close(failures)
+
+	for failure := range failures {
+		t.Fatal(failure)
+	}
+}
@@ -0,0 +1,86 @@
+package rx
+
+import (
+	"sort"
+	"time"
+)
+
+type delayed struct {
+	due   time.Time
+	event interface{}
+}
+
+// delayWith helper, queues events by due time behind a single timer on the
+// queue head so the Observable goroutine is never blocked
+func (id *Observable) delayWith(fn func(interface{}) time.Duration) *Observable {
+	index := len(id.nextOps)
+	queue := []delayed{}
+	completing := false
+	generation := 0
+	var timer Timer
+
+	var schedule func()
+	drain := func(current int) bool {
+		if current != generation {
+			return true
+		}
+		timer = nil
+		now := id.scheduler.Now()
+		for len(queue) != 0 && !queue[0].due.After(now) {
+			event := queue[0].event
+			queue = queue[1:]
+			if !id.onNextFrom(event, index+1) {
+				return false
+			}
+		}
+		if len(queue) != 0 {
+			schedule()
+			return true
+		}
+		if completing {
+			completing = false
+			return id.onComplete(id)
+		}
+		return true
+	}
+	schedule = func() {
+		generation++
+		if timer != nil {
+			timer.Stop()
+		}
+		current := generation
+		timer = id.after(queue[0].due.Sub(id.scheduler.Now()), func() bool {
+			return drain(current)
+		})
+	}
+
+	id.Filter(func(event interface{}) bool {
+		due := id.scheduler.Now().Add(fn(event))
+		if len(queue) == 0 && !due.After(id.scheduler.Now()) {
+			return true
+		}
+		i := sort.Search(len(queue), func(i int) bool {
+			return queue[i].due.After(due)
+		})
+		queue = append(queue, delayed{})
+		copy(queue[i+1:], queue[i:])
+		queue[i] = delayed{due, event}
+		if i == 0 {
+			schedule()
+		}
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorHold, func() bool {
+		completing = len(queue) != 0
+		return completing
+	}}, operator{operatorError, func(err error) {
+		generation++
+		if timer != nil {
+			timer.Stop()
+		}
+		queue = nil
+	}})
+
+	return id
+}
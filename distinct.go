@@ -0,0 +1,159 @@
+package rx
+
+import (
+	"container/list"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DistinctUntilChanged operator, drops events equal to the previous event, a
+// nil equalFn compares with reflect.DeepEqual
+func (id *Observable) DistinctUntilChanged(equalFn func(interface{}, interface{}) bool) *Observable {
+	log.Println(id.UID, "Observable.DistinctUntilChanged")
+	if equalFn == nil {
+		equalFn = reflect.DeepEqual
+	}
+
+	var last interface{}
+	first := true
+	id.Filter(func(event interface{}) bool {
+		if !first && equalFn(last, event) {
+			return false
+		}
+		first = false
+		last = event
+		return true
+	})
+
+	return id
+}
+
+// DistinctUntilKeyChanged operator, drops events whose key equals the key of
+// the previous event, keys that are not comparable are compared by fmt.Sprint
+func (id *Observable) DistinctUntilKeyChanged(keyFn func(interface{}) interface{}) *Observable {
+	log.Println(id.UID, "Observable.DistinctUntilKeyChanged")
+
+	var last interface{}
+	first := true
+	id.Filter(func(event interface{}) bool {
+		key := hashKey(keyFn(event))
+		if !first && key == last {
+			return false
+		}
+		first = false
+		last = key
+		return true
+	})
+
+	return id
+}
+
+// Distinct operator, drops events whose key has been seen before, without a
+// keyFn the event is the key. Keys that are not comparable, such as the
+// map[string]interface{} events of the JSON sources, are keyed by fmt.Sprint.
+// NOTE: every key is remembered, use DistinctBounded on unbounded sources.
+// Distinct used to drop only consecutive duplicates, which is now
+// DistinctUntilChanged.
+func (id *Observable) Distinct(keyFn ...func(interface{}) interface{}) *Observable {
+	log.Println(id.UID, "Observable.Distinct")
+	var fn func(interface{}) interface{}
+	if len(keyFn) != 0 {
+		fn = keyFn[0]
+	}
+	return id.distinctWith(fn, 0, 0)
+}
+
+// DistinctBounded operator, Distinct remembering at most capacity keys with
+// the least recently seen evicted first, and forgetting keys not seen for ttl.
+// A capacity or ttl of 0 is unbounded.
+func (id *Observable) DistinctBounded(keyFn func(interface{}) interface{}, capacity int, ttl time.Duration) *Observable {
+	log.Println(id.UID, "Observable.DistinctBounded")
+	return id.distinctWith(keyFn, capacity, ttl)
+}
+
+type distinctKey struct {
+	key  interface{}
+	seen time.Time
+}
+
+// distinctWith helper, keys are kept in a list ordered by when last seen so
+// the back is both the least recently used and the first to expire
+func (id *Observable) distinctWith(keyFn func(interface{}) interface{}, capacity int, ttl time.Duration) *Observable {
+	keys := map[interface{}]*list.Element{}
+	order := list.New()
+
+	id.Filter(func(event interface{}) bool {
+		key := event
+		if keyFn != nil {
+			key = keyFn(event)
+		}
+		key = hashKey(key)
+
+		var now time.Time
+		if ttl > 0 {
+			now = id.scheduler.Now()
+			for back := order.Back(); back != nil && now.Sub(back.Value.(*distinctKey).seen) >= ttl; back = order.Back() {
+				delete(keys, back.Value.(*distinctKey).key)
+				order.Remove(back)
+			}
+		}
+
+		if element, ok := keys[key]; ok {
+			element.Value.(*distinctKey).seen = now
+			order.MoveToFront(element)
+			return false
+		}
+
+		keys[key] = order.PushFront(&distinctKey{key, now})
+		if capacity > 0 && order.Len() > capacity {
+			back := order.Back()
+			delete(keys, back.Value.(*distinctKey).key)
+			order.Remove(back)
+		}
+		return true
+	})
+
+	return id
+}
+
+// formattedKey type, the key of a value that cannot be compared, distinct
+// from any string key
+type formattedKey struct {
+	value string
+}
+
+// hashKey helper, key itself when it can be compared and used as a map key,
+// otherwise its Go-syntax form, which keeps the types and orders map keys
+func hashKey(key interface{}) interface{} {
+	if isComparable(reflect.ValueOf(key)) {
+		return key
+	}
+	return formattedKey{fmt.Sprintf("%#v", key)}
+}
+
+// isComparable helper, unlike reflect.Type.Comparable it looks into the
+// dynamic values held by interfaces
+func isComparable(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return false
+	case reflect.Interface:
+		return value.IsNil() || isComparable(value.Elem())
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !isComparable(value.Index(i)) {
+				return false
+			}
+		}
+		break
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !isComparable(value.Field(i)) {
+				return false
+			}
+		}
+		break
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package rx
+
+type flatten int
+
+const (
+	flattenMerge flatten = iota
+	flattenSwitch
+	flattenExhaust
+)
+
+// MergeMap operator, subscribes to the Observable returned by fn for each
+// event and emits the inner events as they arrive. At most maxConcurrency
+// inner Observables are subscribed at once, further events are queued, a
+// maxConcurrency below 1 is unbounded.
+func (id *Observable) MergeMap(fn func(interface{}) *Observable, maxConcurrency int) *Observable {
+	log.Println(id.UID, "Observable.MergeMap")
+	return id.flatMap(fn, flattenMerge, maxConcurrency)
+}
+
+// ConcatMap operator, subscribes to the Observable returned by fn for each
+// event once the previous inner Observable has completed
+func (id *Observable) ConcatMap(fn func(interface{}) *Observable) *Observable {
+	log.Println(id.UID, "Observable.ConcatMap")
+	return id.flatMap(fn, flattenMerge, 1)
+}
+
+// SwitchMap operator, subscribes to the Observable returned by fn for each
+// event and unsubscribes from the previous inner Observable
+func (id *Observable) SwitchMap(fn func(interface{}) *Observable) *Observable {
+	log.Println(id.UID, "Observable.SwitchMap")
+	return id.flatMap(fn, flattenSwitch, 0)
+}
+
+// ExhaustMap operator, subscribes to the Observable returned by fn for an
+// event only when no inner Observable is active, other events are dropped
+func (id *Observable) ExhaustMap(fn func(interface{}) *Observable) *Observable {
+	log.Println(id.UID, "Observable.ExhaustMap")
+	return id.flatMap(fn, flattenExhaust, 1)
+}
+
+// flatMap helper, each inner subscription forwards its events to the Observable
+// goroutine as tasks, inner completion never completes id and completion of id
+// is held until the inner Observables complete
+func (id *Observable) flatMap(fn func(interface{}) *Observable, mode flatten, maxConcurrency int) *Observable {
+	index := len(id.nextOps)
+	queue := []interface{}{}
+	active := 0
+	completing := false
+	var current *Observer
+
+	var subscribe func(event interface{})
+	var done func(sub *Observer) bool
+
+	subscribe = func(event interface{}) {
+		inner := fn(event)
+		if inner == nil {
+			return
+		}
+		active++
+		var sub *Observer
+		sub = id.subscribeInner(inner, func(next interface{}) bool {
+			return id.onNextFrom(next, index+1)
+		}, func(err error) bool {
+			active--
+			return id.onError(err)
+		}, func() bool {
+			return done(sub)
+		})
+		current = sub
+	}
+
+	done = func(sub *Observer) bool {
+		active--
+		if current == sub {
+			current = nil
+		}
+		for len(queue) != 0 && (maxConcurrency < 1 || active < maxConcurrency) {
+			event := queue[0]
+			queue = queue[1:]
+			subscribe(event)
+		}
+		if completing && active == 0 && len(queue) == 0 {
+			completing = false
+			return id.onComplete(id)
+		}
+		return true
+	}
+
+	id.Filter(func(event interface{}) bool {
+		switch mode {
+		case flattenSwitch:
+			if current != nil {
+				id.unsubscribeInner(current)
+				active--
+				current = nil
+			}
+			break
+		case flattenExhaust:
+			if active != 0 {
+				return false
+			}
+			break
+		case flattenMerge:
+			if maxConcurrency > 0 && active >= maxConcurrency {
+				queue = append(queue, event)
+				return false
+			}
+			break
+		}
+		subscribe(event)
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorHold, func() bool {
+		completing = active != 0 || len(queue) != 0
+		return completing
+	}}, operator{operatorError, func(err error) {
+		queue = nil
+	}})
+
+	return id
+}
@@ -0,0 +1,129 @@
+package rx
+
+import (
+	"context"
+	"time"
+)
+
+// GroupedObservable type, the Observable of the events sharing Key
+type GroupedObservable struct {
+	*Observable
+	Key interface{}
+}
+
+type group struct {
+	*window
+	generation int
+	timer      Timer
+}
+
+// GroupBy operator, emits a *GroupedObservable for each new key returned by
+// keyFn and routes the events to their group. A group not receiving an event
+// for expiry is completed and a later event with its key opens a new group,
+// an expiry of 0 keeps groups until the source completes. Groups are
+// discarded when they expire or the source finalizes without a subscriber.
+func (id *Observable) GroupBy(keyFn func(interface{}) interface{}, expiry time.Duration) *Observable {
+	log.Println(id.UID, "Observable.GroupBy")
+	index := len(id.nextOps)
+	groups := map[interface{}]*group{}
+
+	closeGroup := func(key interface{}, event Event) {
+		current, ok := groups[key]
+		if !ok {
+			return
+		}
+		delete(groups, key)
+		if current.timer != nil {
+			current.timer.Stop()
+		}
+		if event.Type == EventTypeComplete {
+			event.Complete = current.Observable
+		}
+		current.push(event)
+	}
+
+	closeGroups := func(event Event) {
+		for key := range groups {
+			closeGroup(key, event)
+		}
+	}
+
+	id.Map(func(event interface{}) interface{} {
+		key := keyFn(event)
+		var opened interface{}
+		current, ok := groups[key]
+		if !ok {
+			current = &group{window: newWindow(id)}
+			current.UID = "group." + current.UID
+			groups[key] = current
+			opened = &GroupedObservable{Observable: current.Observable, Key: key}
+		}
+		if expiry > 0 {
+			current.generation++
+			if current.timer != nil {
+				current.timer.Stop()
+			}
+			active := current.generation
+			current.timer = id.after(expiry, func() bool {
+				if groups[key] == current && current.generation == active {
+					closeGroup(key, Event{Type: EventTypeComplete})
+					// an expired group nobody subscribed to is not kept around
+					go current.discard()
+				}
+				return true
+			})
+		}
+		current.push(Event{Type: EventTypeNext, Next: event})
+		// nil when the event went to an already open group
+		return opened
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		closeGroups(Event{Type: EventTypeComplete})
+		return index + 1, nil
+	}}, operator{operatorError, func(err error) {
+		closeGroups(Event{Type: EventTypeError, Error: err})
+	}})
+
+	return id
+}
+
+// Partition operator, splits the events into those passing cond and those
+// failing it through a single subscription to id. Events are buffered until
+// each Observable is subscribed and the subscription ends once both finalize,
+// an Observable still unsubscribed when id finalizes is discarded.
+func (id *Observable) Partition(cond func(interface{}) bool) (*Observable, *Observable) {
+	log.Println(id.UID, "Observable.Partition")
+	pass := newWindow(id)
+	fail := newWindow(id)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-pass.ctx.Done()
+		<-fail.ctx.Done()
+		cancel()
+	}()
+
+	terminate := func(event Event) {
+		for _, partition := range []*window{pass, fail} {
+			if event.Type == EventTypeComplete {
+				event.Complete = partition.Observable
+			}
+			partition.push(event)
+		}
+	}
+
+	id.subscribeFunc(ctx, func(next interface{}) {
+		if cond(next) {
+			pass.push(Event{Type: EventTypeNext, Next: next})
+		} else {
+			fail.push(Event{Type: EventTypeNext, Next: next})
+		}
+	}, func(err error) {
+		terminate(Event{Type: EventTypeError, Error: err})
+	}, func() {
+		terminate(Event{Type: EventTypeComplete})
+	})
+
+	return pass.Observable, fail.Observable
+}
@@ -3,6 +3,7 @@ package rx
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -68,39 +69,47 @@ func NewHTTPClient(timeout time.Duration) *HTTPClient {
 }
 
 // subject export
-func (id *HTTPClient) subject(url string, mime string, data []byte, delimiter byte) (*Observable, error) {
+// The request is bound to the subject context, so it is aborted when ctx is
+// cancelled or when the subject is finalized
+func (id *HTTPClient) subject(ctx context.Context, url string, mime string, data []byte, delimiter byte) (*Observable, error) {
 	log.Println("HTTPRequest.httpSubject")
+	subject := NewSubjectContext(ctx)
+	subject.UID = "httpSubject." + subject.UID
+
 	var req *http.Request
 	var err error
 	if data != nil {
-		req, err = http.NewRequest(http.MethodPost, url, bytes.NewBuffer(data))
+		req, err = http.NewRequestWithContext(subject.ctx, http.MethodPost, url, bytes.NewBuffer(data))
 	} else {
-		req, err = http.NewRequest(http.MethodGet, url, nil)
+		req, err = http.NewRequestWithContext(subject.ctx, http.MethodGet, url, nil)
 	}
 
 	if err != nil {
 		log.Println("HTTPRequest.httpSubject", err)
+		subject.cancel()
 		return nil, err
 	}
 
 	req.Header.Add("Accept", mime)
 	req.Header.Add("Connection", "close")
 
-	subject := NewSubject()
-	subject.UID = "httpSubject." + subject.UID
-
 	var wg sync.WaitGroup
 	wg.Add(1)
 
 	go func() {
 		wg.Done()
 		// wait for connect
-		<-subject.connect
+		select {
+		case <-subject.connect:
+			break
+		case <-subject.ctx.Done():
+			return
+		}
 
 		// perform the request
 		resp, err := id.Client.Do(req)
 		if err != nil {
-			subject.onError(err)
+			subject.emit(Event{Type: EventTypeError, Error: err})
 			return
 		}
 		defer resp.Body.Close()
@@ -118,31 +127,33 @@ func (id *HTTPClient) subject(url string, mime string, data []byte, delimiter by
 				data, err := ioutil.ReadAll(reader)
 				if err != nil {
 					log.Println(subject.UID, "HTTPRequest.httpSubject.Error", err)
-					subject.onError(err)
+					subject.emit(Event{Type: EventTypeError, Error: err})
+					return
+				}
+				if !subject.emit(Event{Type: EventTypeNext, Next: data}) {
 					return
 				}
-				subject.onNext(data)
 				log.Println(subject.UID, "HTTPRequest.httpSubject.Complete via ReadAll")
-				subject.Yield()
-				subject.onComplete(subject)
+				subject.emit(Event{Type: EventTypeComplete, Complete: subject})
 				return
 			}
 			chunk, err := reader.ReadBytes(delimiter)
 			if err != nil && err != io.EOF {
 				log.Println(subject.UID, "HTTPRequest.httpSubject.Error", err)
-				subject.onError(err)
+				subject.emit(Event{Type: EventTypeError, Error: err})
 				return
 			}
 			chunkLength := len(chunk)
 			if chunkLength != 0 {
 				dlog.Println(subject.UID, "HTTPRequest.httpSubject.Next")
-				subject.onNext(chunk)
+				if !subject.emit(Event{Type: EventTypeNext, Next: chunk}) {
+					return
+				}
 				if contentLength > 0 {
 					contentLength -= int64(chunkLength)
 					if contentLength <= 0 {
 						dlog.Println(subject.UID, "HTTPRequest.httpSubject.Complete via ContentLength")
-						subject.Yield()
-						subject.onComplete(subject)
+						subject.emit(Event{Type: EventTypeComplete, Complete: subject})
 						return
 					}
 				}
@@ -150,8 +161,7 @@ func (id *HTTPClient) subject(url string, mime string, data []byte, delimiter by
 			// end of read
 			if err == io.EOF {
 				dlog.Println(subject.UID, "HTTPRequest.httpSubject.Complete via EOF")
-				subject.Yield()
-				subject.onComplete(subject)
+				subject.emit(Event{Type: EventTypeComplete, Complete: subject})
 				return
 			}
 		}
@@ -163,13 +173,18 @@ func (id *HTTPClient) subject(url string, mime string, data []byte, delimiter by
 
 // NewHTTPByteSubject HTTP response of Observable<[]byte>
 func NewHTTPByteSubject(url string, contentType string, payload []byte) (*Observable, error) {
+	return NewHTTPByteSubjectContext(context.Background(), url, contentType, payload)
+}
+
+// NewHTTPByteSubjectContext HTTP response of Observable<[]byte>
+func NewHTTPByteSubjectContext(ctx context.Context, url string, contentType string, payload []byte) (*Observable, error) {
 	log.Println("HTTPRequest.ByteSubject")
-	subject := NewSubject()
+	subject := NewSubjectContext(ctx)
 	client := NewHTTPClient(5 * time.Second)
 
 	subject.Resubscribe(func(observer *Observable) error {
 		log.Println(observer.UID, "HTTPRequest.ByteSubject.Resubscribe")
-		httpSubject, err := client.subject(url, contentType, payload, 0)
+		httpSubject, err := client.subject(observer.ctx, url, contentType, payload, 0)
 		if err != nil {
 			return err
 		}
@@ -184,13 +199,18 @@ func NewHTTPByteSubject(url string, contentType string, payload []byte) (*Observ
 
 // NewHTTPTextSubject HTTP response of Observable<string>
 func NewHTTPTextSubject(url string, payload []byte) (*Observable, error) {
+	return NewHTTPTextSubjectContext(context.Background(), url, payload)
+}
+
+// NewHTTPTextSubjectContext HTTP response of Observable<string>
+func NewHTTPTextSubjectContext(ctx context.Context, url string, payload []byte) (*Observable, error) {
 	log.Println("HTTPRequest.TextSubject")
-	subject := NewSubject()
+	subject := NewSubjectContext(ctx)
 	client := NewHTTPClient(5 * time.Second)
 
 	subject.Resubscribe(func(observer *Observable) error {
 		log.Println(observer.UID, "HTTPRequest.TextSubject.Resubscribe")
-		httpSubject, err := client.subject(url, "text/plain", payload, 0)
+		httpSubject, err := client.subject(observer.ctx, url, "text/plain", payload, 0)
 		if err != nil {
 			return err
 		}
@@ -211,13 +231,18 @@ func NewHTTPTextSubject(url string, payload []byte) (*Observable, error) {
 
 // NewHTTPLineSubject HTTP response of Observable<[]byte> delimited by newlines
 func NewHTTPLineSubject(url string, payload []byte) (*Observable, error) {
+	return NewHTTPLineSubjectContext(context.Background(), url, payload)
+}
+
+// NewHTTPLineSubjectContext HTTP response of Observable<[]byte> delimited by newlines
+func NewHTTPLineSubjectContext(ctx context.Context, url string, payload []byte) (*Observable, error) {
 	log.Println("HTTPRequest.LineSubject")
-	subject := NewSubject()
+	subject := NewSubjectContext(ctx)
 	client := NewHTTPClient(5 * time.Second)
 
 	subject.Resubscribe(func(observer *Observable) error {
 		log.Println(observer.UID, "HTTPRequest.LineSubject.Resubscribe")
-		httpSubject, err := client.subject(url, "text/plain", payload, byte('\n'))
+		httpSubject, err := client.subject(observer.ctx, url, "text/plain", payload, byte('\n'))
 		if err != nil {
 			return err
 		}
@@ -232,13 +257,18 @@ func NewHTTPLineSubject(url string, payload []byte) (*Observable, error) {
 
 // NewHTTPJSONSubject export
 func NewHTTPJSONSubject(url string, payload []byte) (*Observable, error) {
+	return NewHTTPJSONSubjectContext(context.Background(), url, payload)
+}
+
+// NewHTTPJSONSubjectContext export
+func NewHTTPJSONSubjectContext(ctx context.Context, url string, payload []byte) (*Observable, error) {
 	log.Println("HTTPRequest.JSONSubject")
-	subject := NewSubject()
+	subject := NewSubjectContext(ctx)
 	client := NewHTTPClient(5 * time.Second)
 
 	subject.Resubscribe(func(observer *Observable) error {
 		log.Println(observer.UID, "HTTPRequest.JSONSubject.Resubscribe")
-		httpSubject, err := client.subject(url, "application/json", payload, 0)
+		httpSubject, err := client.subject(observer.ctx, url, "application/json", payload, 0)
 		if err != nil {
 			return err
 		}
@@ -250,7 +280,7 @@ func NewHTTPJSONSubject(url string, payload []byte) (*Observable, error) {
 			var result interface{}
 			err := json.Unmarshal(data, &result)
 			if err != nil {
-				subject.onError(err)
+				subject.emit(Event{Type: EventTypeError, Error: err})
 				return nil
 			}
 			return result
@@ -266,8 +296,13 @@ func NewHTTPJSONSubject(url string, payload []byte) (*Observable, error) {
 
 // NewHTTPSSESubject export
 func NewHTTPSSESubject(url string, payload []byte) (*Observable, error) {
+	return NewHTTPSSESubjectContext(context.Background(), url, payload)
+}
+
+// NewHTTPSSESubjectContext export
+func NewHTTPSSESubjectContext(ctx context.Context, url string, payload []byte) (*Observable, error) {
 	log.Println("HTTPRequest.SSESubject")
-	subject := NewSubject()
+	subject := NewSubjectContext(ctx)
 	client := NewHTTPClient(5 * time.Second)
 
 	subject.Resubscribe(func(observer *Observable) error {
@@ -277,7 +312,7 @@ func NewHTTPSSESubject(url string, payload []byte) (*Observable, error) {
 		lines := make([][]byte, 10)
 		i := 0
 
-		httpSubject, err := client.subject(url, "text/event-stream", payload, byte('\n'))
+		httpSubject, err := client.subject(observer.ctx, url, "text/event-stream", payload, byte('\n'))
 		if err != nil {
 			return err
 		}
@@ -1,8 +1,13 @@
 package rx
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestRequestText(t *testing.T) {
@@ -173,3 +178,106 @@ loop:
 		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
 	}
 }
+
+func TestRequestContext(t *testing.T) {
+	aborted := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("line\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		aborted <- true
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subject, err := NewHTTPLineSubjectContext(ctx, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Init error %v", err)
+		return
+	}
+
+	errorCnt := 0
+
+	observer := NewObserver()
+	subject.Subscribe <- observer
+loop:
+	for {
+		select {
+		case event := <-observer.Event:
+			switch event.Type {
+			case EventTypeNext:
+				cancel()
+				break
+			case EventTypeError:
+				errorCnt++
+				break loop
+			case EventTypeComplete:
+				t.Fatalf("Unexpected complete")
+				return
+			}
+		}
+	}
+
+	if errorCnt != 1 {
+		t.Fatalf("Expected error count of %v but got %v", 1, errorCnt)
+	}
+
+	select {
+	case <-aborted:
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected request to be aborted")
+	}
+}
+
+func TestRequestLineOrdering(t *testing.T) {
+	lines := 100
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < lines; i++ {
+			w.Write([]byte(strconv.Itoa(i) + "\n"))
+		}
+	}))
+	defer server.Close()
+
+	subject, err := NewHTTPLineSubject(server.URL, nil)
+	if err != nil {
+		t.Fatalf("Init error %v", err)
+		return
+	}
+
+	next := 0
+	completeCnt := 0
+
+	observer := NewObserver()
+	subject.Subscribe <- observer
+loop:
+	for {
+		select {
+		case event := <-observer.Event:
+			switch event.Type {
+			case EventTypeNext:
+				line := strings.TrimSpace(string(event.Next.([]byte)))
+				if line != strconv.Itoa(next) {
+					t.Fatalf("Expected line %v but got %v", next, line)
+				}
+				next++
+				break
+			case EventTypeError:
+				t.Fatalf("Error %v", event.Error)
+				return
+			case EventTypeComplete:
+				completeCnt++
+				break loop
+			}
+		}
+	}
+
+	if next != lines {
+		t.Fatalf("Expected line count of %v but got %v", lines, next)
+	}
+	if completeCnt != 1 {
+		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
+	}
+}
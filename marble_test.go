@@ -0,0 +1,751 @@
+package rx_test
+
+import (
+	"testing"
+	"time"
+
+	rx "github.com/mlavergn/rxgo"
+	"github.com/mlavergn/rxgo/rxtest"
+)
+
+func TestMarbleDistinctUntilChanged(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-a-b-b-a-|", nil, nil).DistinctUntilChanged(nil)
+	tester.Expect(source, "-a---b---a-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleDistinctUntilKeyChanged(t *testing.T) {
+	values := map[string]interface{}{
+		"a": map[string]interface{}{"id": 1, "ts": 10},
+		"b": map[string]interface{}{"id": 1, "ts": 20},
+		"c": map[string]interface{}{"id": 2, "ts": 30},
+	}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-|", values, nil).DistinctUntilKeyChanged(func(event interface{}) interface{} {
+		return event.(map[string]interface{})["id"]
+	})
+	tester.Expect(source, "-a---c-|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleDistinct(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-a-b-b-a-c-|", nil, nil).Distinct()
+	tester.Expect(source, "-a---b-----c-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleDistinctMaps(t *testing.T) {
+	values := map[string]interface{}{
+		"a": map[string]interface{}{"id": 1.0},
+		"b": map[string]interface{}{"id": 2.0},
+		"c": map[string]interface{}{"id": 1.0},
+	}
+
+	tester := rxtest.New(t)
+	distinct := tester.Cold("-a-b-c-|", values, nil).Distinct()
+	tester.Expect(distinct, "-a-b---|", values, nil)
+	changed := tester.Cold("-a-c-b-|", values, nil).DistinctUntilKeyChanged(func(event interface{}) interface{} {
+		return event
+	})
+	tester.Expect(changed, "-a---b-|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleDistinctTypes(t *testing.T) {
+	values := map[string]interface{}{
+		"a": map[string]interface{}{"id": 1.0},
+		"b": map[string]interface{}{"id": "1"},
+		"c": "map[id:1]",
+	}
+
+	tester := rxtest.New(t)
+	// the events differ only in the type of the id
+	distinct := tester.Cold("-a-b-c-|", values, nil).Distinct()
+	tester.Expect(distinct, "-a-b-c-|", values, nil)
+	changed := tester.Cold("-a-b-c-|", values, nil).DistinctUntilKeyChanged(func(event interface{}) interface{} {
+		return event
+	})
+	tester.Expect(changed, "-a-b-c-|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleDistinctBounded(t *testing.T) {
+	tester := rxtest.New(t)
+	lru := tester.Cold("-a-b-c-a-c-|", nil, nil).DistinctBounded(nil, 2, 0)
+	tester.Expect(lru, "-a-b-c-a---|", nil, nil)
+	ttl := tester.Cold("-a-a---a-b-|", nil, nil).DistinctBounded(nil, 0, 3*tester.Frame)
+	tester.Expect(ttl, "-a-----a-b-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleStartWith(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Hot("^-b-c-|", nil, nil)
+	source.StartWith(func() []interface{} {
+		return []interface{}{"a"}
+	})
+	tester.Expect(source, "a-b-c-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleMerge(t *testing.T) {
+	tester := rxtest.New(t)
+	left := tester.Hot("-a---c-|", nil, nil)
+	right := tester.Hot("---b---d-|", nil, nil)
+	merged := rx.NewSubject().WithScheduler(tester.Scheduler)
+	merged.Merge(left).Merge(right)
+	tester.Expect(merged, "-a-b-c-d-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleScan(t *testing.T) {
+	values := map[string]interface{}{"a": 1, "b": 2, "c": 3, "x": 1, "y": 3, "z": 6}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-|", values, nil)
+	source.Scan(0, func(acc interface{}, event interface{}) interface{} {
+		return acc.(int) + event.(int)
+	})
+	tester.Expect(source, "-x-y-z-|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleReduce(t *testing.T) {
+	values := map[string]interface{}{"a": 1, "b": 2, "c": 3, "z": 60}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-|", values, nil)
+	source.Reduce(0, func(acc interface{}, event interface{}) interface{} {
+		return acc.(int) + event.(int)
+	}).Map(func(event interface{}) interface{} {
+		return event.(int) * 10
+	})
+	tester.Expect(source, "-------(z|)", values, nil)
+	tester.Run()
+}
+
+func TestMarbleReduceError(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-#", nil, nil)
+	source.Reduce("", func(acc interface{}, event interface{}) interface{} {
+		return acc.(string) + event.(string)
+	})
+	tester.Expect(source, "-----#", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleBufferCount(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"a", "b"},
+		"y": []interface{}{"c", "d"},
+		"z": []interface{}{"e"},
+	}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-d-e-|", nil, nil).BufferCount(2)
+	tester.Expect(source, "---x---y---(z|)", values, nil)
+	tester.Run()
+}
+
+func TestMarbleBufferTime(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"a", "b"},
+		"y": []interface{}{"c"},
+	}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-----c-|", nil, nil)
+	source.BufferTime(3 * tester.Frame)
+	tester.Expect(source, "----x------(y|)", values, nil)
+	tester.Run()
+}
+
+func TestMarbleBufferWithCountOrTime(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"a", "b"},
+		"y": []interface{}{"c"},
+		"z": []interface{}{"d"},
+	}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-ab--c----d-|", nil, nil)
+	source.BufferWithCountOrTime(2, 3*tester.Frame)
+	tester.Expect(source, "--x-----y---(z|)", values, nil)
+	tester.Run()
+}
+
+func TestMarbleBufferError(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-#", nil, nil).BufferCount(3)
+	tester.Expect(source, "-----#", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleDebounce(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a--bc----d-|", nil, nil).Debounce(2 * tester.Frame)
+	tester.Expect(source, "---a---c----(d|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleThrottleLeading(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-abc-d---e-|", nil, nil).ThrottleTime(3*tester.Frame, true, false)
+	tester.Expect(source, "-a---d---e-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleThrottleTrailing(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-abc-d---e-|", nil, nil).ThrottleTime(3*tester.Frame, false, true)
+	tester.Expect(source, "----c--d--e|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleAuditTime(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-ab----c-|", nil, nil).AuditTime(3 * tester.Frame)
+	tester.Expect(source, "----b----(c|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleSample(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-ab--c--d|", nil, nil).Sample(3 * tester.Frame)
+	tester.Expect(source, "---b--c--|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleSampleWith(t *testing.T) {
+	tester := rxtest.New(t)
+	notifier := tester.Hot("---x---x---x", nil, nil)
+	source := tester.Cold("-ab---c----|", nil, nil).SampleWith(notifier)
+	tester.Expect(source, "---b---c---|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleDelay(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c|", nil, nil).Delay(2 * tester.Frame)
+	tester.Expect(source, "---a-b-(c|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleDelayWhen(t *testing.T) {
+	values := map[string]interface{}{"a": 3, "b": 1}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-ab--|", values, nil).DelayWhen(func(event interface{}) time.Duration {
+		return time.Duration(event.(int)) * tester.Frame
+	})
+	tester.Expect(source, "---ba|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleDelayError(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b#", nil, nil).Delay(2 * tester.Frame)
+	tester.Expect(source, "---a#", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleMergeMap(t *testing.T) {
+	tester := rxtest.New(t)
+	inners := map[interface{}]*rx.Observable{
+		"a": tester.Cold("-1-2|", nil, nil),
+		"b": tester.Cold("-3-4|", nil, nil),
+	}
+	source := tester.Cold("-a--b---|", nil, nil).MergeMap(func(event interface{}) *rx.Observable {
+		return inners[event]
+	}, 0)
+	tester.Expect(source, "--1-23-4|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleMergeMapShared(t *testing.T) {
+	tester := rxtest.New(t)
+	shared := tester.Hot("----x--|", nil, nil)
+	source := tester.Cold("-a-b|", nil, nil).MergeMap(func(event interface{}) *rx.Observable {
+		return shared
+	}, 0)
+	tester.Expect(source, "----(xx)--|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleConcatMap(t *testing.T) {
+	tester := rxtest.New(t)
+	inners := map[interface{}]*rx.Observable{
+		"a": tester.Cold("-1-2|", nil, nil),
+		"b": tester.Cold("-3-4|", nil, nil),
+	}
+	source := tester.Cold("-a--b---|", nil, nil).ConcatMap(func(event interface{}) *rx.Observable {
+		return inners[event]
+	})
+	tester.Expect(source, "--1-2-3-4|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleSwitchMap(t *testing.T) {
+	tester := rxtest.New(t)
+	inners := map[interface{}]*rx.Observable{
+		"a": tester.Cold("-1-2|", nil, nil),
+		"b": tester.Cold("-3-4|", nil, nil),
+	}
+	source := tester.Cold("-a-b----|", nil, nil).SwitchMap(func(event interface{}) *rx.Observable {
+		return inners[event]
+	})
+	tester.Expect(source, "--1-3-4-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleExhaustMap(t *testing.T) {
+	tester := rxtest.New(t)
+	inners := map[interface{}]*rx.Observable{
+		"a": tester.Cold("-1-2|", nil, nil),
+		"b": tester.Cold("-3-4|", nil, nil),
+		"c": tester.Cold("-5-6|", nil, nil),
+	}
+	source := tester.Cold("-a-b---c----|", nil, nil).ExhaustMap(func(event interface{}) *rx.Observable {
+		return inners[event]
+	})
+	tester.Expect(source, "--1-2---5-6-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleMergeMapError(t *testing.T) {
+	tester := rxtest.New(t)
+	inner := tester.Cold("-1#", nil, nil)
+	source := tester.Cold("-a------|", nil, nil).MergeMap(func(event interface{}) *rx.Observable {
+		return inner
+	}, 0)
+	tester.Expect(source, "--1#", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleCombineLatest(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"a", "1"},
+		"y": []interface{}{"a", "2"},
+		"z": []interface{}{"b", "2"},
+	}
+
+	tester := rxtest.New(t)
+	left := tester.Cold("-a---b-|", nil, nil)
+	right := tester.Cold("--1-2---|", nil, nil)
+	tester.Expect(rx.CombineLatest(left, right), "--x-yz--|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleZip(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"a", "1"},
+		"y": []interface{}{"b", "2"},
+		"z": []interface{}{"c", "3"},
+	}
+
+	tester := rxtest.New(t)
+	left := tester.Cold("-a-b-c|", nil, nil)
+	right := tester.Cold("--1---2-3-|", nil, nil)
+	tester.Expect(rx.Zip(left, right), "--x---y-(z|)", values, nil)
+	tester.Run()
+}
+
+func TestMarbleZipSame(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"a", "a"},
+		"y": []interface{}{"b", "b"},
+	}
+
+	tester := rxtest.New(t)
+	source := tester.Hot("-a-b-|", nil, nil)
+	tester.Expect(rx.Zip(source, source), "-x-y-|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleForkJoin(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"b", "2"},
+	}
+
+	tester := rxtest.New(t)
+	left := tester.Cold("-a-b|", nil, nil)
+	right := tester.Cold("--1---2|", nil, nil)
+	tester.Expect(rx.ForkJoin(left, right), "-------(x|)", values, nil)
+	tester.Run()
+}
+
+func TestMarbleForkJoinEmpty(t *testing.T) {
+	tester := rxtest.New(t)
+	left := tester.Cold("-a-b|", nil, nil)
+	right := tester.Cold("--|", nil, nil)
+	tester.Expect(rx.ForkJoin(left, right), "--|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleWithLatestFrom(t *testing.T) {
+	values := map[string]interface{}{
+		"x": []interface{}{"b", "1"},
+		"y": []interface{}{"c", "2"},
+	}
+
+	tester := rxtest.New(t)
+	other := tester.Hot("---1--2---", nil, nil)
+	source := tester.Cold("-a--b--c-|", nil, nil).WithLatestFrom(other)
+	tester.Expect(source, "----x--y-|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleConcat(t *testing.T) {
+	tester := rxtest.New(t)
+	first := tester.Cold("-a-b|", nil, nil)
+	second := tester.Cold("--c-|", nil, nil)
+	tester.Expect(rx.Concat(first, second), "-a-b--c-|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleRace(t *testing.T) {
+	tester := rxtest.New(t)
+	slow := tester.Cold("---a-b|", nil, nil)
+	fast := tester.Cold("-1-2-3|", nil, nil)
+	tester.Expect(rx.Race(slow, fast), "-1-2-3|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleEndWith(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b|", nil, nil).EndWith(func() []interface{} {
+		return []interface{}{"c"}
+	})
+	tester.Expect(source, "-a-b(c|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleSkip(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-d|", nil, nil).Skip(2)
+	tester.Expect(source, "-----c-d|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleSkipWhile(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-a|", nil, nil).SkipWhile(func(event interface{}) bool {
+		return event != "c"
+	})
+	tester.Expect(source, "-----c-a|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleSkipUntil(t *testing.T) {
+	tester := rxtest.New(t)
+	notifier := tester.Hot("----x", nil, nil)
+	source := tester.Cold("-a-b-c-d|", nil, nil).SkipUntil(notifier)
+	tester.Expect(source, "-----c-d|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleSkipLast(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-d|", nil, nil).SkipLast(2)
+	tester.Expect(source, "-----a-b|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleTakeLast(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-c-d|", nil, nil).TakeLast(2)
+	tester.Expect(source, "--------(cd|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleFirst(t *testing.T) {
+	tester := rxtest.New(t)
+	first := tester.Cold("-a-b|", nil, nil).First(nil)
+	tester.Expect(first, "-(a|)", nil, nil)
+	missing := tester.Cold("-a-b|", nil, nil).First(func(event interface{}) bool {
+		return event == "c"
+	})
+	tester.Expect(missing, "----#", nil, rx.ErrNoElements)
+	tester.Run()
+}
+
+func TestMarbleLast(t *testing.T) {
+	tester := rxtest.New(t)
+	last := tester.Cold("-a-b|", nil, nil).Last(nil)
+	tester.Expect(last, "----(b|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleElementAt(t *testing.T) {
+	tester := rxtest.New(t)
+	element := tester.Cold("-a-b-c|", nil, nil).ElementAt(1)
+	tester.Expect(element, "---(b|)", nil, nil)
+	missing := tester.Cold("-a-b-c|", nil, nil).ElementAt(5)
+	tester.Expect(missing, "------#", nil, rx.ErrNoElements)
+	negative := tester.Cold("-a-b-c|", nil, nil).ElementAt(-1)
+	tester.Expect(negative, "-#", nil, rx.ErrArgumentOutOfRange)
+	tester.Run()
+}
+
+func TestMarbleSingle(t *testing.T) {
+	tester := rxtest.New(t)
+	single := tester.Cold("-a--|", nil, nil).Single()
+	tester.Expect(single, "----(a|)", nil, nil)
+	many := tester.Cold("-a-b-|", nil, nil).Single()
+	tester.Expect(many, "---#", nil, rx.ErrTooManyElements)
+	tester.Run()
+}
+
+func TestMarbleTakeWhile(t *testing.T) {
+	cond := func(event interface{}) bool {
+		return event != "c"
+	}
+
+	tester := rxtest.New(t)
+	exclusive := tester.Cold("-a-b-c-d|", nil, nil).TakeWhile(cond, false)
+	tester.Expect(exclusive, "-a-b-|", nil, nil)
+	inclusive := tester.Cold("-a-b-c-d|", nil, nil).TakeWhile(cond, true)
+	tester.Expect(inclusive, "-a-b-(c|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleTakeStacked(t *testing.T) {
+	tester := rxtest.New(t)
+	stacked := tester.Cold("-a-b-c-d|", nil, nil).Take(3).Skip(1).Take(1)
+	tester.Expect(stacked, "---(b|)", nil, nil)
+	narrowed := tester.Cold("-a-b-c-d|", nil, nil).Take(5).Take(2)
+	tester.Expect(narrowed, "-a-(b|)", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleGroupBy(t *testing.T) {
+	values := map[string]interface{}{"x": 2, "y": 1}
+
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-a-----a-|", nil, nil).GroupBy(func(event interface{}) interface{} {
+		return event
+	}, 5*tester.Frame).MergeMap(func(event interface{}) *rx.Observable {
+		return event.(*rx.GroupedObservable).Reduce(0, func(acc interface{}, event interface{}) interface{} {
+			return acc.(int) + 1
+		})
+	}, 0)
+	tester.Expect(source, "--------y-x--(y|)", values, nil)
+	tester.Run()
+}
+
+func TestMarblePartition(t *testing.T) {
+	values := map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4}
+
+	tester := rxtest.New(t)
+	even, odd := tester.Cold("-a-b-c-d|", values, nil).Partition(func(event interface{}) bool {
+		return event.(int)%2 == 0
+	})
+	tester.Expect(even, "---b---d|", values, nil)
+	tester.Expect(odd, "-a---c--|", values, nil)
+	tester.Run()
+}
+
+func TestMarbleTimeout(t *testing.T) {
+	tester := rxtest.New(t)
+	source := tester.Cold("-a-b-----c|", nil, nil).Timeout(3 * tester.Frame)
+	tester.Expect(source, "-a-b--#", nil, rx.ErrTimeout)
+	tester.Run()
+}
+
+func TestMarbleTimeoutFirst(t *testing.T) {
+	tester := rxtest.New(t)
+	late := tester.Cold("---a------b|", nil, nil).TimeoutFirst(2 * tester.Frame)
+	tester.Expect(late, "--#", nil, rx.ErrTimeout)
+	early := tester.Cold("-a------b|", nil, nil).TimeoutFirst(2 * tester.Frame)
+	tester.Expect(early, "-a------b|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleTimeoutWith(t *testing.T) {
+	tester := rxtest.New(t)
+	fallback := tester.Cold("-x-y|", nil, nil)
+	source := tester.Cold("-a----b|", nil, nil).TimeoutWith(2*tester.Frame, fallback)
+	tester.Expect(source, "-a--x-y|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleTimeoutWithUpstream(t *testing.T) {
+	tester := rxtest.New(t)
+	upstream := tester.Hot("-a----b#", nil, nil)
+	fallback := tester.Cold("-x---y|", nil, nil)
+	source := rx.NewSubject().WithScheduler(tester.Scheduler)
+	upstream.Pipe(source)
+	tester.Expect(source.TimeoutWith(2*tester.Frame, fallback), "-a--x---y|", nil, nil)
+	tester.Run()
+
+	// the upstream is unsubscribed on the switch
+	select {
+	case <-upstream.Finalize:
+		break
+	default:
+		t.Fatalf("Expected upstream Finalize after the switch to fallback")
+	}
+}
+
+func TestMarbleTimeoutWithMergeMap(t *testing.T) {
+	tester := rxtest.New(t)
+	fallback := tester.Cold("-x--|", nil, nil)
+	source := tester.Cold("-a------", nil, nil).MergeMap(func(next interface{}) *rx.Observable {
+		return tester.Cold("-1----|", nil, nil)
+	}, 0).TimeoutWith(3*tester.Frame, fallback)
+	// the inner subscription outlives the switch and completion waits for it
+	tester.Expect(source, "--1---x--|", nil, nil)
+	tester.Run()
+}
+
+func TestMarbleTimeoutWithRetry(t *testing.T) {
+	tester := rxtest.New(t)
+	sources := []*rx.Observable{
+		tester.Cold("-a----", nil, nil),
+		tester.Cold("-b|", nil, nil),
+	}
+	attempt := 0
+	subject := rx.NewSubject().WithScheduler(tester.Scheduler)
+	subject.TimeoutWith(3*tester.Frame, tester.Cold("-#", nil, nil)).RetryWhen(func() bool {
+		return true
+	})
+	tester.Expect(subject, "-a----b|", nil, nil)
+	subject.Resubscribe(func(observer *rx.Observable) error {
+		sources[attempt].Pipe(observer)
+		attempt++
+		return nil
+	})
+	tester.Run()
+}
+
+func TestMarbleTimeoutRetry(t *testing.T) {
+	tester := rxtest.New(t)
+	sources := []*rx.Observable{
+		tester.Cold("-a----", nil, nil),
+		tester.Cold("-b|", nil, nil),
+	}
+	attempt := 0
+	subject := rx.NewSubject().WithScheduler(tester.Scheduler)
+	subject.Timeout(3 * tester.Frame).RetryWhen(func() bool {
+		return true
+	})
+	tester.Expect(subject, "-a---b|", nil, nil)
+	subject.Resubscribe(func(observer *rx.Observable) error {
+		sources[attempt].Pipe(observer)
+		attempt++
+		return nil
+	})
+	tester.Run()
+}
+
+func TestMarbleRetryWithPolicy(t *testing.T) {
+	tester := rxtest.New(t)
+	sources := []*rx.Observable{
+		tester.Cold("-a#", nil, nil),
+		tester.Cold("--#", nil, nil),
+		tester.Cold("-c|", nil, nil),
+	}
+	attempts := []int{}
+	attempt := 0
+	subject := rx.NewSubject().WithScheduler(tester.Scheduler)
+	subject.RetryWithPolicy(rx.RetryPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: 2 * tester.Frame,
+		Multiplier:     2,
+		OnRetry: func(attempt int, err error) {
+			if err != rxtest.ErrMarble {
+				t.Errorf("Expected retry error %v but got %v", rxtest.ErrMarble, err)
+			}
+			attempts = append(attempts, attempt)
+		},
+	})
+	tester.Expect(subject, "-a---------c|", nil, nil)
+	subject.Resubscribe(func(observer *rx.Observable) error {
+		sources[attempt].Pipe(observer)
+		attempt++
+		return nil
+	})
+	tester.Run()
+
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Fatalf("Expected attempts %v but got %v", []int{1, 2}, attempts)
+	}
+}
+
+func TestMarbleRetryWithPolicyExhausted(t *testing.T) {
+	tester := rxtest.New(t)
+	sources := []*rx.Observable{
+		tester.Cold("-a#", nil, nil),
+		tester.Cold("--#", nil, nil),
+	}
+	attempt := 0
+	subject := rx.NewSubject().WithScheduler(tester.Scheduler)
+	subject.RetryWithPolicy(rx.RetryPolicy{
+		MaxAttempts:    1,
+		InitialBackoff: 2 * tester.Frame,
+	})
+	tester.Expect(subject, "-a----#", nil, nil)
+	subject.Resubscribe(func(observer *rx.Observable) error {
+		sources[attempt].Pipe(observer)
+		attempt++
+		return nil
+	})
+	tester.Run()
+}
+
+func TestMarbleRetryWithPolicyReset(t *testing.T) {
+	tester := rxtest.New(t)
+	sources := []*rx.Observable{
+		tester.Cold("-a#", nil, nil),
+		tester.Cold("-b#", nil, nil),
+		tester.Cold("-c#", nil, nil),
+		tester.Cold("-d|", nil, nil),
+	}
+	attempts := []int{}
+	attempt := 0
+	subject := rx.NewSubject().WithScheduler(tester.Scheduler)
+	subject.RetryWithPolicy(rx.RetryPolicy{
+		MaxAttempts:    1,
+		InitialBackoff: 2 * tester.Frame,
+		Multiplier:     2,
+		OnRetry: func(attempt int, err error) {
+			attempts = append(attempts, attempt)
+		},
+	})
+	// each resubscription emits before failing, so the count starts over
+	tester.Expect(subject, "-a---b---c---d|", nil, nil)
+	subject.Resubscribe(func(observer *rx.Observable) error {
+		sources[attempt].Pipe(observer)
+		attempt++
+		return nil
+	})
+	tester.Run()
+
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[1] != 1 || attempts[2] != 1 {
+		t.Fatalf("Expected attempts %v but got %v", []int{1, 1, 1}, attempts)
+	}
+}
+
+func TestMarbleRepeatWithPolicy(t *testing.T) {
+	tester := rxtest.New(t)
+	sources := []*rx.Observable{
+		tester.Cold("-a|", nil, nil),
+		tester.Cold("-b|", nil, nil),
+	}
+	attempt := 0
+	subject := rx.NewSubject().WithScheduler(tester.Scheduler)
+	subject.RepeatWithPolicy(rx.RetryPolicy{
+		MaxAttempts:    1,
+		InitialBackoff: 3 * tester.Frame,
+	})
+	tester.Expect(subject, "-a----b|", nil, nil)
+	subject.Resubscribe(func(observer *rx.Observable) error {
+		sources[attempt].Pipe(observer)
+		attempt++
+		return nil
+	})
+	tester.Run()
+}
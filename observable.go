@@ -1,6 +1,8 @@
 package rx
 
 import (
+	"context"
+	"runtime"
 	"sync"
 	"time"
 )
@@ -12,6 +14,11 @@ const (
 	operatorFilter
 	operatorTap
 	operatorStartWith
+	operatorFlush
+	operatorError
+	operatorHold
+	operatorTake
+	operatorCheck
 )
 
 type operator struct {
@@ -22,14 +29,20 @@ type operator struct {
 // Observable type
 type Observable struct {
 	*Observer
-	pipes          map[*Observable]*Observable
+	pipes          map[*Observable]*Observer
+	inners         map[*Observer]*Observable
 	pipesMutex     sync.RWMutex
 	observers      map[*Observer]*Observer
 	observersMutex sync.RWMutex
 	publish        bool
 	connect        chan bool
+	source         bool
+	connected      bool
 	connecters     map[*Observer]*Observer
 	share          bool
+	refCount       bool
+	disconnected   bool
+	detached       bool
 	multicast      bool
 	merge          bool
 	Subscribe      chan *Observer
@@ -37,39 +50,66 @@ type Observable struct {
 	Unsubscribe    chan *Observer
 	completeOnce   sync.Once
 	Finalize       chan bool
+	tasks          chan func() bool
+	pending        work
+	ctx            context.Context
+	cancel         context.CancelFunc
+	scheduler      Scheduler
 	buffer         *CircularBuffer
+	replayWindow   time.Duration
+	persist        *persistence
 	nextOps        []operator
+	completeOps    []operator
 	repeatWhenFn   func() bool
 	retryWhenFn    func() bool
+	retryPolicyFn  func(error) (time.Duration, bool)
+	repeatPolicyFn func(error) (time.Duration, bool)
 	catchErrorFn   func(error)
 	resubscribeFn  func(*Observable) error
-	takeFn         func() bool
 }
 
 // NewObservable init
 func NewObservable() *Observable {
+	return NewObservableContext(context.Background())
+}
+
+// NewObservableContext init, cancelling ctx errors the Observable with ctx.Err()
+func NewObservableContext(ctx context.Context) *Observable {
 	log.Println("Observable.NewObservable")
+	ctx, cancel := context.WithCancel(ctx)
 	id := &Observable{
-		Observer:      NewObserver(),
-		pipes:         map[*Observable]*Observable{},
-		observers:     make(map[*Observer]*Observer, 1),
-		publish:       false,
-		connect:       make(chan bool, 1),
-		connecters:    map[*Observer]*Observer{},
-		share:         false,
-		multicast:     false,
-		merge:         false,
-		Subscribe:     make(chan *Observer, 1),
-		subscribeOps:  []operator{},
-		Unsubscribe:   make(chan *Observer, 1),
-		Finalize:      make(chan bool, 1),
-		buffer:        nil,
-		nextOps:       []operator{},
-		repeatWhenFn:  nil,
-		retryWhenFn:   nil,
-		catchErrorFn:  nil,
-		resubscribeFn: nil,
-		takeFn:        nil,
+		Observer:       NewObserver(),
+		pipes:          map[*Observable]*Observer{},
+		inners:         map[*Observer]*Observable{},
+		observers:      make(map[*Observer]*Observer, 1),
+		publish:        false,
+		connect:        make(chan bool, 1),
+		connecters:     map[*Observer]*Observer{},
+		share:          false,
+		refCount:       false,
+		disconnected:   false,
+		detached:       false,
+		multicast:      false,
+		merge:          false,
+		Subscribe:      make(chan *Observer, 1),
+		subscribeOps:   []operator{},
+		Unsubscribe:    make(chan *Observer, 1),
+		Finalize:       make(chan bool, 1),
+		tasks:          make(chan func() bool, 1),
+		ctx:            ctx,
+		cancel:         cancel,
+		scheduler:      DefaultScheduler,
+		buffer:         nil,
+		replayWindow:   0,
+		persist:        nil,
+		nextOps:        []operator{},
+		completeOps:    []operator{},
+		repeatWhenFn:   nil,
+		retryWhenFn:    nil,
+		retryPolicyFn:  nil,
+		repeatPolicyFn: nil,
+		catchErrorFn:   nil,
+		resubscribeFn:  nil,
 	}
 
 	// block to allow the reader goroutine to spin up
@@ -79,20 +119,47 @@ func NewObservable() *Observable {
 	go func() {
 		defer func() {
 			log.Println(id.UID, "Observable.Finalize")
-			id.Finalize <- true
-			id.Yield()
-			close(id.Finalize)
-			id.complete(id) // cleanup the Observer member
-			close(id.connect)
-			close(id.Subscribe)
-			close(id.Unsubscribe)
+			// whatever is still queued will never be handled
+			id.pending.seal()
+			id.Observer.work.seal()
+			// cancel first so sources and HTTP requests stop producing
+			id.cancel()
+			id.clearPipes()
+			// the channels are left open, senders racing the finalization
+			// select on ctx rather than panic on a closed channel, and
+			// any upstream blocked on the Observer member is released
+			id.Observer.cancel()
+			id.observersMutex.Lock()
 			id.observers = nil
+			id.observersMutex.Unlock()
 			id.nextOps = nil
+			id.completeOps = nil
+			// signal last so that readers of Finalize see the cleared state
+			id.Finalize <- true
+			close(id.Finalize)
 		}()
 		wg.Done()
 		for {
+			// subscriptions take priority so that an observer subscribed
+			// ahead of an event is guaranteed to receive it
 			select {
-			case event := <-id.Event:
+			case observer := <-id.Subscribe:
+				dlog.Println(id.UID, "Observable<-Subscribe")
+				id.onSubscribe(observer)
+				id.pending.done()
+				continue
+			default:
+			}
+			// the Observer is replaced on resubscribe
+			current := id.Observer
+			select {
+			case event := <-current.Event:
+				// the upstream was replaced by an inner subscription
+				if id.detached {
+					dlog.Println(id.UID, "Observable<-Event detached")
+					current.work.done()
+					break
+				}
 				switch event.Type {
 				case EventTypeNext:
 					dlog.Println(id.UID, "Observable<-Next")
@@ -113,17 +180,33 @@ func NewObservable() *Observable {
 					}
 					return
 				}
+				// released once handled, any work it caused is counted by now
+				current.work.done()
 				break
 			case observer := <-id.Subscribe:
 				dlog.Println(id.UID, "Observable<-Subscribe")
 				id.onSubscribe(observer)
+				id.pending.done()
 				break
 			case observer := <-id.Unsubscribe:
 				dlog.Println(id.UID, "Observable<-Unsubscribe")
 				if id.onUnsubscribe(observer) {
+					id.pending.done()
 					break
 				}
 				return
+			case task := <-id.tasks:
+				dlog.Println(id.UID, "Observable<-Task")
+				if task() {
+					id.pending.done()
+					break
+				}
+				return
+			case <-id.ctx.Done():
+				dlog.Println(id.UID, "Observable<-Done", id.ctx.Err())
+				id.onFlush(id.ctx.Err())
+				id.doComplete(id.ctx.Err())
+				return
 			}
 		}
 	}()
@@ -133,21 +216,49 @@ func NewObservable() *Observable {
 	return id
 }
 
+// emit sends event to the Observable unless it has been finalized
+func (id *Observable) emit(event Event) bool {
+	observer := id.Observer
+	observer.work.add()
+	select {
+	case observer.Event <- event:
+		return true
+	case <-id.ctx.Done():
+		observer.work.done()
+		return false
+	}
+}
+
+// post runs task on the Observable goroutine, a task returning false finalizes
+// the Observable. Used by timers and inner subscriptions, it must never be
+// called from the Observable goroutine itself.
+func (id *Observable) post(task func() bool) bool {
+	id.pending.add()
+	select {
+	case id.tasks <- task:
+		return true
+	case <-id.ctx.Done():
+		id.pending.done()
+		return false
+	}
+}
+
+// after posts task to the Observable goroutine once d has elapsed
+func (id *Observable) after(d time.Duration, task func() bool) Timer {
+	return id.scheduler.AfterFunc(d, func() {
+		id.post(task)
+	})
+}
+
 func (id *Observable) doComplete(err error) bool {
 	id.completeOnce.Do(func() {
 		id.observersMutex.Lock()
 		for _, observer := range id.observers {
 			delete(id.observers, observer)
 			if err != nil {
-				select {
-				case observer.Event <- Event{Type: EventTypeError, Error: err}:
-				default:
-				}
+				observer.terminate(Event{Type: EventTypeError, Error: err})
 			} else {
-				select {
-				case observer.Event <- Event{Type: EventTypeComplete, Complete: id}:
-				default:
-				}
+				observer.terminate(Event{Type: EventTypeComplete, Complete: id})
 			}
 		}
 		id.observersMutex.Unlock()
@@ -158,51 +269,181 @@ func (id *Observable) doComplete(err error) bool {
 	return true
 }
 
-func (id *Observable) addPipe(pipe *Observable) {
+// addPipe records observer as subscribed to pipe on behalf of id
+func (id *Observable) addPipe(pipe *Observable, observer *Observer) {
 	id.pipesMutex.Lock()
-	id.pipes[pipe] = pipe
+	id.pipes[pipe] = observer
 	id.pipesMutex.Unlock()
 }
 
 func (id *Observable) delPipe(pipe *Observable, unsubscribe bool) {
 	id.pipesMutex.Lock()
+	observer, ok := id.pipes[pipe]
 	delete(id.pipes, pipe)
 	id.pipesMutex.Unlock()
-	if unsubscribe {
-		select {
-		case pipe.Unsubscribe <- id.Observer:
-		default:
-		}
+	if unsubscribe && ok {
+		unsubscribePipe(pipe, observer)
+	}
+}
+
+// addInner records the inner subscription observer, an inner Observable may
+// be subscribed several times so the subscription is keyed by its Observer
+func (id *Observable) addInner(observer *Observer, inner *Observable) {
+	id.pipesMutex.Lock()
+	id.inners[observer] = inner
+	id.pipesMutex.Unlock()
+}
+
+// hasInner returns true while the inner subscription observer is recorded
+func (id *Observable) hasInner(observer *Observer) bool {
+	id.pipesMutex.RLock()
+	defer id.pipesMutex.RUnlock()
+	_, ok := id.inners[observer]
+	return ok
+}
+
+func (id *Observable) delInner(observer *Observer, unsubscribe bool) {
+	id.pipesMutex.Lock()
+	inner, ok := id.inners[observer]
+	delete(id.inners, observer)
+	id.pipesMutex.Unlock()
+	if unsubscribe && ok {
+		unsubscribePipe(inner, observer)
+	}
+}
+
+// unsubscribePipe helper, removes observer from pipe
+func unsubscribePipe(pipe *Observable, observer *Observer) {
+	pipe.pending.add()
+	select {
+	case pipe.Unsubscribe <- observer:
+	default:
+		// never drop the unsubscribe, a busy pipe would otherwise keep
+		// emitting to observer indefinitely
+		go func() {
+			select {
+			case pipe.Unsubscribe <- observer:
+			case <-pipe.ctx.Done():
+				pipe.pending.done()
+			}
+		}()
 	}
 }
 
 func (id *Observable) clearPipes() {
-	// remove and unsubscribe from all merged
+	// remove and unsubscribe from all merged and inner subscriptions
 	if len(id.pipes) != 0 {
 		for pipe := range id.pipes {
 			id.delPipe(pipe, true)
 		}
 	}
+	if len(id.inners) != 0 {
+		for observer := range id.inners {
+			id.delInner(observer, true)
+		}
+	}
+}
+
+// subscribeInner subscribes to inner on behalf of id, events are forwarded to
+// the Observable goroutine as tasks and ignored once the subscription has been
+// removed. The subscription is removed ahead of onError or onComplete. Returns
+// the Observer identifying the subscription for unsubscribeInner.
+func (id *Observable) subscribeInner(inner *Observable, onNext func(interface{}) bool, onError func(error) bool, onComplete func() bool) *Observer {
+	observer := NewObserver()
+	observer.work.track(id.scheduler)
+	id.addInner(observer, inner)
+
+	// block to allow the forwarding goroutine to spin up
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer observer.work.seal()
+		wg.Done()
+		for {
+			select {
+			case event := <-observer.Event:
+				switch event.Type {
+				case EventTypeNext:
+					next := event.Next
+					if !id.post(func() bool {
+						if !id.hasInner(observer) {
+							return true
+						}
+						return onNext(next)
+					}) {
+						return
+					}
+					// released once forwarded as a task
+					observer.work.done()
+					break
+				case EventTypeError:
+					err := event.Error
+					id.post(func() bool {
+						if !id.hasInner(observer) {
+							return true
+						}
+						id.delInner(observer, false)
+						return onError(err)
+					})
+					return
+				case EventTypeComplete:
+					id.post(func() bool {
+						if !id.hasInner(observer) {
+							return true
+						}
+						id.delInner(observer, false)
+						return onComplete()
+					})
+					return
+				}
+			case <-observer.done:
+				return
+			case <-id.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	inner.pending.add()
+	inner.Subscribe <- observer
+
+	return observer
+}
+
+// unsubscribeInner removes the inner subscription observer and releases it
+func (id *Observable) unsubscribeInner(observer *Observer) {
+	if id.hasInner(observer) {
+		observer.cancel()
+		id.delInner(observer, true)
+	}
 }
 
 // onNext handler
 func (id *Observable) onNext(event interface{}) bool {
 	log.Println(id.UID, "Observable.onNext")
+	return id.onNextFrom(event, 0)
+}
 
+// onNextFrom handler, applies the operations from index onwards so that
+// operators can emit events held back by their own position in nextOps
+func (id *Observable) onNextFrom(event interface{}, index int) bool {
 	// Operations
-	for _, op := range id.nextOps {
+	last := false
+	for _, op := range id.nextOps[index:] {
 		switch op.op {
 		case operatorFilter:
 			filterFn := op.fn.(func(interface{}) bool)
 			if filterFn(event) != true {
-				return true
+				return id.onTake(last)
 			}
 			break
 		case operatorMap:
 			mapFn := op.fn.(func(interface{}) interface{})
 			mappedEvent := mapFn(event)
 			if mappedEvent == nil {
-				return true
+				return id.onTake(last)
 			}
 			event = mappedEvent
 			break
@@ -210,30 +451,50 @@ func (id *Observable) onNext(event interface{}) bool {
 			tapFn := op.fn.(func(interface{}))
 			tapFn(event)
 			break
+		case operatorTake:
+			takeFn := op.fn.(func(interface{}) (bool, bool))
+			emit, more := takeFn(event)
+			last = last || !more
+			if !emit {
+				return id.onTake(last)
+			}
+			break
 		}
 	}
 
-	// Replay / Distinct
+	// Replay
 	if id.buffer != nil {
-		id.buffer.Add(event)
+		id.replayAdd(event)
 	}
 
 	// multicast the event
+	overflowed := []*Observer{}
 	id.observersMutex.RLock()
 	for _, observer := range id.observers {
 		observer.next(event)
+		if observer.overflowed() {
+			overflowed = append(overflowed, observer)
+		}
 	}
 	id.observersMutex.RUnlock()
 
-	// Take
-	if id.takeFn != nil && !id.takeFn() {
-		dlog.Println(id.UID, "Take complete")
-		id.Yield()
-		id.onComplete(id)
-		return false
+	// an observer errored by its buffer is unsubscribed as if it had asked
+	for _, observer := range overflowed {
+		if !id.onUnsubscribe(observer) {
+			return false
+		}
 	}
 
-	return true
+	return id.onTake(last)
+}
+
+// onTake handler, completes once a take operation has seen its last event
+func (id *Observable) onTake(last bool) bool {
+	if !last {
+		return true
+	}
+	dlog.Println(id.UID, "Take complete")
+	return id.onComplete(id)
 }
 
 // onError handler
@@ -250,6 +511,7 @@ func (id *Observable) onError(err error) bool {
 		return true
 	}
 
+	id.onFlush(err)
 	id.doComplete(err)
 
 	return false
@@ -278,11 +540,73 @@ func (id *Observable) onComplete(obs *Observable) bool {
 		}
 	}
 
+	if proceed, running := id.onFlush(nil); !proceed {
+		return running
+	}
+
 	id.doComplete(nil)
 
 	return false
 }
 
+// onFlush handler, emits the events held by operators ahead of completion
+// or notifies them of err. An operator holding completion leaves the remaining
+// operations in completeOps and resumes with onComplete once drained, an
+// operator check failing turns completion into an error. Returns whether
+// completion may proceed and whether the Observable is still running.
+func (id *Observable) onFlush(err error) (bool, bool) {
+	log.Println(id.UID, "Observable.onFlush")
+
+	// operations flush once, completion triggered while flushing skips them
+	ops := id.completeOps
+	id.completeOps = nil
+	for i, op := range ops {
+		switch op.op {
+		case operatorHold:
+			if err != nil {
+				break
+			}
+			holdFn := op.fn.(func() bool)
+			if holdFn() {
+				log.Println(id.UID, "Observable<-Complete blocked by pending operator")
+				id.completeOps = ops[i:]
+				return false, true
+			}
+			break
+		case operatorCheck:
+			if err != nil {
+				break
+			}
+			checkFn := op.fn.(func() error)
+			if cerr := checkFn(); cerr != nil {
+				id.completeOps = ops[i+1:]
+				return false, id.onError(cerr)
+			}
+			break
+		case operatorError:
+			if err != nil {
+				errorFn := op.fn.(func(error))
+				errorFn(err)
+			}
+			break
+		case operatorFlush:
+			if err != nil {
+				break
+			}
+			flushFn := op.fn.(func() (int, []interface{}))
+			index, events := flushFn()
+			for _, event := range events {
+				if !id.onNextFrom(event, index) {
+					return false, false
+				}
+			}
+			break
+		}
+	}
+
+	return true, true
+}
+
 // onSubscribe handler
 func (id *Observable) onSubscribe(observer *Observer) bool {
 	log.Println(id.UID, "Observable.onSubscribe")
@@ -297,12 +621,21 @@ func (id *Observable) onSubscribe(observer *Observer) bool {
 	if !id.multicast {
 		for _, observer := range id.observers {
 			delete(id.observers, observer)
-			observer.Event <- Event{Type: EventTypeComplete, Complete: id}
+			observer.terminate(Event{Type: EventTypeComplete, Complete: id})
 		}
 	}
 	id.observers[observer] = observer
 	id.observersMutex.Unlock()
 
+	// connect a deferred Resubscribe or reconnect a ref counted Observable
+	if id.disconnected {
+		id.disconnected = false
+		if id.resubscribeFn != nil {
+			log.Println(id.UID, "Observable.onSubscribe reconnect")
+			id.resubscribeFn(id)
+		}
+	}
+
 	// subscription operations
 	for _, op := range id.subscribeOps {
 		switch op.op {
@@ -319,30 +652,35 @@ func (id *Observable) onSubscribe(observer *Observer) bool {
 	// replay for the new sub
 	if id.buffer != nil {
 		log.Println(id.UID, "Observable.onSubscribe replay")
-		i := -1
-		for {
-			var v interface{}
-			i, v = id.buffer.Next(i)
-			if i == -1 {
-				break
-			}
+		id.replayEvict()
+		for _, v := range id.replayValues() {
 			observer.next(v)
 		}
 	}
 
 	// if no publish hold, send Connect
 	if id.publish == false {
-		select {
-		case id.connect <- true:
-			break
-		default:
-			break
-		}
+		id.signalConnect()
 	}
 
 	return false
 }
 
+// signalConnect helper, wakes the source goroutine waiting on connect, which
+// holds the first connect as work in flight until it has started
+func (id *Observable) signalConnect() {
+	if id.source && !id.connected {
+		id.connected = true
+		id.pending.add()
+	}
+	select {
+	case id.connect <- true:
+		break
+	default:
+		break
+	}
+}
+
 // onUnsubscribe handler
 func (id *Observable) onUnsubscribe(observer *Observer) bool {
 	log.Println(id.UID, "Observable.onUnsubscribe")
@@ -352,9 +690,37 @@ func (id *Observable) onUnsubscribe(observer *Observer) bool {
 	if len(id.observers) > 0 || id.share {
 		return true
 	}
+	if id.refCount && id.resubscribeFn != nil {
+		id.disconnect()
+		return true
+	}
 	return false
 }
 
+// detach handler, unsubscribes from the upstream pipes and drops the events
+// of the upstream until resubscribed, used by operators that replace the
+// upstream. Inner subscriptions belong to other operators and are kept.
+func (id *Observable) detach() {
+	log.Println(id.UID, "Observable.detach")
+	for pipe := range id.pipes {
+		id.delPipe(pipe, true)
+	}
+	id.detached = true
+}
+
+// disconnect handler, unsubscribes from the upstream pipes which finalize and
+// cancel their requests, the Observable itself stays alive. Without a
+// Resubscribe function there is no way back to the upstream, so nothing is
+// disconnected.
+func (id *Observable) disconnect() {
+	log.Println(id.UID, "Observable.disconnect")
+	if id.resubscribeFn == nil {
+		return
+	}
+	id.clearPipes()
+	id.disconnected = true
+}
+
 // onResubscribe handler
 func (id *Observable) onResubscribe(err error) bool {
 	log.Println(id.UID, "Observable.onResubscribe")
@@ -366,31 +732,75 @@ func (id *Observable) onResubscribe(err error) bool {
 	if id.resubscribeFn != nil {
 		dlog.Println(id.UID, "Observable.onResubscribe.resubscribeFn")
 		id.clearPipes()
+		id.detached = false
 		oldObserver := id.Observer
 		id.Observer = NewObserver()
+		id.Observer.work.track(id.scheduler)
 		oldObserver.complete(id)
+		oldObserver.work.seal()
 		if err != nil {
 			dlog.Println(id.UID, "Observable.onResubscribe.retryWhen")
 			if id.retryWhenFn != nil && id.retryWhenFn() {
 				id.resubscribeFn(id)
 				return true
 			}
+			if id.retryPolicyFn != nil {
+				if backoff, ok := id.retryPolicyFn(err); ok {
+					id.resubscribeAfter(backoff)
+					return true
+				}
+			}
 		} else {
 			dlog.Println(id.UID, "Observable.onResubscribe.repeatWhen")
 			if id.repeatWhenFn != nil && id.repeatWhenFn() {
 				id.resubscribeFn(id)
 				return true
 			}
+			if id.repeatPolicyFn != nil {
+				if backoff, ok := id.repeatPolicyFn(nil); ok {
+					id.resubscribeAfter(backoff)
+					return true
+				}
+			}
 		}
 	}
 	return false
 }
 
-// Yield staggers sending to a blocked select in order to avoid
-// situations where multiple-cases can proceed causing the select
-// to pseudo-random rather that FIFO across the channels
+// SubscribeContext subscribes observer and unsubscribes it once ctx is done,
+// the observer receives no further events after cancellation and should be discarded
+func (id *Observable) SubscribeContext(ctx context.Context, observer *Observer) *Observable {
+	log.Println(id.UID, "Observable.SubscribeContext")
+	id.pending.add()
+	id.Subscribe <- observer
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			dlog.Println(id.UID, "Observable.SubscribeContext cancelled", ctx.Err())
+			observer.cancel()
+			id.pending.add()
+			select {
+			case id.Unsubscribe <- observer:
+				break
+			case <-id.ctx.Done():
+				id.pending.done()
+				break
+			}
+		case <-id.ctx.Done():
+			break
+		}
+	}()
+
+	return id
+}
+
+// Yield lets other goroutines run
+// Deprecated: events from a single source are delivered in order through the
+// Event channel and terminal events are never dropped, so sending no longer
+// needs to be staggered
 func (id *Observable) Yield() *Observable {
-	<-time.After(1 * time.Millisecond)
+	runtime.Gosched()
 	return id
 }
 
@@ -404,6 +814,59 @@ func (id *Observable) Share() *Observable {
 	return id
 }
 
+// RefCount modifier, connects on the first subscriber and disconnects from
+// the upstream when the last observer unsubscribes. The Observable stays
+// alive and reconnects through its Resubscribe function on the next subscriber.
+// Without Resubscribe, e.g. a source such as NewInterval or an upstream attached
+// through Pipe, it cannot be reconnected and finalizes with its last observer.
+func (id *Observable) RefCount() *Observable {
+	log.Println(id.UID, "Observable.RefCount")
+	id.refCount = true
+	id.post(func() bool {
+		if id.publish {
+			id.publish = false
+			for o := range id.connecters {
+				delete(id.connecters, o)
+				id.onSubscribe(o)
+			}
+		}
+		if len(id.observers) == 0 {
+			id.disconnect()
+		}
+		return true
+	})
+	return id
+}
+
+// ShareReplay modifier, multicasts replaying the last bufferSize events to
+// late subscribers. With refCount the upstream is disconnected while there
+// are no observers, otherwise it is kept alive as with Share. The replay
+// buffer is kept across reconnects.
+func (id *Observable) ShareReplay(bufferSize int, refCount bool) *Observable {
+	log.Println(id.UID, "Observable.ShareReplay", bufferSize, refCount)
+	id.multicast = true
+	id.setReplay(bufferSize)
+	if refCount {
+		return id.RefCount()
+	}
+	return id.Share()
+}
+
+// WithScheduler modifier, replaces the Scheduler used by time based
+// sources and operators, must be applied before subscribing
+func (id *Observable) WithScheduler(scheduler Scheduler) *Observable {
+	log.Println(id.UID, "Observable.WithScheduler")
+	id.setScheduler(scheduler)
+	return id
+}
+
+// setScheduler helper, a TestScheduler also accounts for the work sent to id
+func (id *Observable) setScheduler(scheduler Scheduler) {
+	id.scheduler = scheduler
+	id.pending.track(scheduler)
+	id.Observer.work.track(scheduler)
+}
+
 // Publish modifier
 func (id *Observable) Publish() *Observable {
 	id.publish = true
@@ -416,12 +879,7 @@ func (id *Observable) Connect() *Observable {
 		id.onSubscribe(o)
 	}
 	id.publish = false
-	select {
-	case id.connect <- true:
-		break
-	default:
-		break
-	}
+	id.signalConnect()
 
 	return id
 }
@@ -448,11 +906,29 @@ func (id *Observable) setReplay(bufferSize int) *Observable {
 	return id
 }
 
-// Resubscribe modifier
+// setReplayWindow modifier
+func (id *Observable) setReplayWindow(bufferSize int, window time.Duration) *Observable {
+	log.Println(id.UID, "Observable.ReplayWindow", bufferSize, window)
+	id.buffer = NewCircularBuffer(bufferSize)
+	id.replayWindow = window
+	return id
+}
+
+// Resubscribe modifier, fn connects the upstream to the Observable given,
+// it is first called once there is a subscriber and again on each retry,
+// repeat or RefCount reconnect
 func (id *Observable) Resubscribe(fn func(*Observable) error) *Observable {
 	log.Println(id.UID, "Observable.Resubscribe", fn != nil)
-	id.resubscribeFn = fn
-	fn(id)
+	id.post(func() bool {
+		id.resubscribeFn = fn
+		if len(id.observers) == 0 {
+			log.Println(id.UID, "Observable.Resubscribe deferred until subscribed")
+			id.disconnected = true
+			return true
+		}
+		fn(id)
+		return true
+	})
 	return id
 }
 
@@ -1,8 +1,10 @@
 package rx
 
 import (
+	"errors"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,34 +26,186 @@ type Event struct {
 	Complete *Observable
 }
 
+// BackpressureStrategy type
+type BackpressureStrategy int
+
+// Backpressure strategies applied when an Observer falls behind
+const (
+	// BackpressureBlock blocks the Observable until the Observer reads
+	BackpressureBlock BackpressureStrategy = iota
+	// BackpressureDropNewest drops incoming events while the buffer is full
+	BackpressureDropNewest
+	// BackpressureDropOldest evicts the oldest buffered event for the incoming event
+	BackpressureDropOldest
+	// BackpressureKeepLatest buffers only the most recent event
+	BackpressureKeepLatest
+	// BackpressureBuffer errors the Observer with ErrBufferOverflow once the buffer is full
+	BackpressureBuffer
+)
+
+// ErrBufferOverflow export
+var ErrBufferOverflow = errors.New("rx: observer buffer overflow")
+
 // Observer type
 type Observer struct {
+	dropped      uint64 // atomic, first for 64-bit alignment
+	closed       uint32 // atomic, set by cancel from any goroutine
+	strategy     BackpressureStrategy
 	Event        chan Event
 	finalizeOnce sync.Once
-	closed       bool
+	cancelOnce   sync.Once
+	done         chan struct{}
+	work         work
 	UID          string
 }
 
 // NewObserver init
 func NewObserver() *Observer {
+	return NewBackpressureObserver(BackpressureBlock, 1)
+}
+
+// NewBackpressureObserver init, bufferSize is ignored by BackpressureKeepLatest
+func NewBackpressureObserver(strategy BackpressureStrategy, bufferSize int) *Observer {
 	log.Println("Observer.NewObserver")
+	if strategy == BackpressureKeepLatest || bufferSize < 1 {
+		bufferSize = 1
+	}
 	id := &Observer{
-		Event:  make(chan Event, 1),
-		closed: false,
-		UID:    strconv.FormatInt(time.Now().UnixNano(), 10),
+		strategy: strategy,
+		Event:    make(chan Event, bufferSize),
+		done:     make(chan struct{}),
+		UID:      strconv.FormatInt(time.Now().UnixNano(), 10),
 	}
 
 	return id
 }
 
+// Dropped returns the count of events dropped by the backpressure strategy
+func (id *Observer) Dropped() uint64 {
+	return atomic.LoadUint64(&id.dropped)
+}
+
+// isClosed helper
+func (id *Observer) isClosed() bool {
+	return atomic.LoadUint32(&id.closed) == 1
+}
+
+// close helper, stops delivery of further events
+func (id *Observer) close() {
+	atomic.StoreUint32(&id.closed, 1)
+}
+
+// overflowed helper, true once a BackpressureBuffer Observer has overflowed
+func (id *Observer) overflowed() bool {
+	return id.strategy == BackpressureBuffer && id.isClosed()
+}
+
 // next helper
 func (id *Observer) next(event interface{}) *Observer {
 	log.Println(id.UID, "Observer.next")
 
-	if !id.closed && event != nil {
-		id.Event <- Event{Type: EventTypeNext, Next: event}
+	if id.isClosed() || event == nil {
+		return id
+	}
+
+	next := Event{Type: EventTypeNext, Next: event}
+	id.work.add()
+	switch id.strategy {
+	case BackpressureDropNewest:
+		select {
+		case id.Event <- next:
+			break
+		default:
+			atomic.AddUint64(&id.dropped, 1)
+			id.work.done()
+		}
+		break
+	case BackpressureDropOldest, BackpressureKeepLatest:
+		for {
+			select {
+			case id.Event <- next:
+				return id
+			default:
+			}
+			select {
+			case <-id.Event:
+				atomic.AddUint64(&id.dropped, 1)
+				id.work.done()
+			default:
+			}
+		}
+	case BackpressureBuffer:
+		select {
+		case id.Event <- next:
+			break
+		default:
+			atomic.AddUint64(&id.dropped, 1)
+			id.work.done()
+			id.terminate(Event{Type: EventTypeError, Error: ErrBufferOverflow})
+			id.close()
+		}
+		break
+	default:
+		select {
+		case id.Event <- next:
+			break
+		case <-id.done:
+			id.work.done()
+			break
+		}
+	}
+
+	return id
+}
+
+// terminate helper, delivers a terminal event without ever dropping it
+func (id *Observer) terminate(event Event) *Observer {
+	log.Println(id.UID, "Observer.terminate")
+
+	if id.isClosed() {
+		return id
+	}
+
+	id.work.add()
+	if id.strategy == BackpressureBlock {
+		select {
+		case id.Event <- event:
+			break
+		case <-id.done:
+			id.work.done()
+			break
+		}
+		return id
 	}
 
+	select {
+	case id.Event <- event:
+		break
+	default:
+		// deliver once the consumer catches up without stalling the Observable
+		go func() {
+			select {
+			case id.Event <- event:
+				break
+			case <-id.done:
+				id.work.done()
+				break
+			}
+		}()
+	}
+
+	return id
+}
+
+// cancel helper, stops delivery including any blocked send
+func (id *Observer) cancel() *Observer {
+	log.Println(id.UID, "Observer.cancel")
+
+	id.cancelOnce.Do(func() {
+		id.close()
+		close(id.done)
+	})
+
 	return id
 }
 
@@ -60,7 +214,8 @@ func (id *Observer) error(err error) *Observer {
 	log.Println(id.UID, "Observer.error")
 
 	id.finalizeOnce.Do(func() {
-		id.closed = true
+		id.close()
+		id.work.add()
 		id.Event <- Event{Type: EventTypeError, Error: err}
 		close(id.Event)
 	})
@@ -73,7 +228,8 @@ func (id *Observer) complete(obs *Observable) *Observer {
 	log.Println(id.UID, "Observer.complete")
 
 	id.finalizeOnce.Do(func() {
-		id.closed = true
+		id.close()
+		id.work.add()
 		id.Event <- Event{Type: EventTypeComplete, Complete: obs}
 		close(id.Event)
 	})
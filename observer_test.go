@@ -0,0 +1,109 @@
+package rx
+
+import (
+	"testing"
+	"time"
+)
+
+func backpressureEvents(subject *Observable, events int) {
+	for i := 1; i <= events; i++ {
+		subject.Event <- Event{Type: EventTypeNext, Next: i}
+	}
+	subject.Event <- Event{Type: EventTypeComplete, Complete: subject}
+	<-subject.Finalize
+}
+
+func TestBackpressureDropNewest(t *testing.T) {
+	observer := NewBackpressureObserver(BackpressureDropNewest, 2)
+	subject := NewSubject()
+	subject.Subscribe <- observer
+	backpressureEvents(subject, 5)
+
+	expect := []int{1, 2}
+	for _, value := range expect {
+		event := <-observer.Event
+		if event.Type != EventTypeNext || event.Next != value {
+			t.Fatalf("Expected next value %v but got %v", value, event)
+		}
+	}
+	event := <-observer.Event
+	if event.Type != EventTypeComplete {
+		t.Fatalf("Expected complete but got %v", event.Type)
+	}
+	if observer.Dropped() != 3 {
+		t.Fatalf("Expected dropped count of %v but got %v", 3, observer.Dropped())
+	}
+}
+
+func TestBackpressureDropOldest(t *testing.T) {
+	observer := NewBackpressureObserver(BackpressureDropOldest, 2)
+	subject := NewSubject()
+	subject.Subscribe <- observer
+	backpressureEvents(subject, 5)
+
+	expect := []int{4, 5}
+	for _, value := range expect {
+		event := <-observer.Event
+		if event.Type != EventTypeNext || event.Next != value {
+			t.Fatalf("Expected next value %v but got %v", value, event)
+		}
+	}
+	event := <-observer.Event
+	if event.Type != EventTypeComplete {
+		t.Fatalf("Expected complete but got %v", event.Type)
+	}
+	if observer.Dropped() != 3 {
+		t.Fatalf("Expected dropped count of %v but got %v", 3, observer.Dropped())
+	}
+}
+
+func TestBackpressureKeepLatest(t *testing.T) {
+	observer := NewBackpressureObserver(BackpressureKeepLatest, 0)
+	subject := NewSubject()
+	subject.Subscribe <- observer
+	backpressureEvents(subject, 5)
+
+	event := <-observer.Event
+	if event.Type != EventTypeNext || event.Next != 5 {
+		t.Fatalf("Expected next value %v but got %v", 5, event)
+	}
+	event = <-observer.Event
+	if event.Type != EventTypeComplete {
+		t.Fatalf("Expected complete but got %v", event.Type)
+	}
+	if observer.Dropped() != 4 {
+		t.Fatalf("Expected dropped count of %v but got %v", 4, observer.Dropped())
+	}
+}
+
+func TestBackpressureBuffer(t *testing.T) {
+	observer := NewBackpressureObserver(BackpressureBuffer, 2)
+	subject := NewSubject()
+	subject.Subscribe <- observer
+	for i := 1; i <= 3; i++ {
+		subject.Event <- Event{Type: EventTypeNext, Next: i}
+	}
+
+	// the overflowed observer was the last, so the subject finalizes
+	select {
+	case <-subject.Finalize:
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected Finalize after the only observer overflowed")
+	}
+
+	expect := []int{1, 2}
+	for _, value := range expect {
+		event := <-observer.Event
+		if event.Type != EventTypeNext || event.Next != value {
+			t.Fatalf("Expected next value %v but got %v", value, event)
+		}
+	}
+	event := <-observer.Event
+	if event.Type != EventTypeError || event.Error != ErrBufferOverflow {
+		t.Fatalf("Expected error %v but got %v", ErrBufferOverflow, event)
+	}
+	if observer.Dropped() != 1 {
+		t.Fatalf("Expected dropped count of %v but got %v", 1, observer.Dropped())
+	}
+}
@@ -1,14 +1,14 @@
 package rx
 
 import (
-	"fmt"
 	"time"
 )
 
 // Pipe pipes events to an observerable
 func (id *Observable) Pipe(observable *Observable) *Observable {
 	log.Println(id.UID, "Observable.Pipe")
-	observable.addPipe(id)
+	observable.addPipe(id, observable.Observer)
+	id.pending.add()
 	id.Subscribe <- observable.Observer
 	return id
 }
@@ -43,28 +43,46 @@ func (id *Observable) Tap(fn func(interface{})) *Observable {
 	return id
 }
 
-// Distinct operator
-func (id *Observable) Distinct() *Observable {
-	log.Println(id.UID, "Observable.Distinct")
-	last := ""
+// Scan operator, emits each intermediate accumulation
+func (id *Observable) Scan(seed interface{}, fn func(interface{}, interface{}) interface{}) *Observable {
+	log.Println(id.UID, "Observable.Scan")
+	acc := seed
+	id.Map(func(event interface{}) interface{} {
+		acc = fn(acc, event)
+		return acc
+	})
+	return id
+}
+
+// Reduce operator, emits the final accumulation on completion
+func (id *Observable) Reduce(seed interface{}, fn func(interface{}, interface{}) interface{}) *Observable {
+	log.Println(id.UID, "Observable.Reduce")
+	acc := seed
+	index := len(id.nextOps)
 	id.Filter(func(event interface{}) bool {
-		eventStr := fmt.Sprint(event)
-		if eventStr != last {
-			last = eventStr
-			return true
-		}
+		acc = fn(acc, event)
 		return false
 	})
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		return index + 1, []interface{}{acc}
+	}})
 	return id
 }
 
-// Delay operator
+// Delay operator, shifts each event by delay preserving order, completion is
+// delayed until pending events are emitted
 func (id *Observable) Delay(delay time.Duration) *Observable {
 	log.Println(id.UID, "Observable.Delay")
-	id.Tap(func(event interface{}) {
-		<-time.After(delay)
+	return id.delayWith(func(event interface{}) time.Duration {
+		return delay
 	})
-	return id
+}
+
+// DelayWhen operator, shifts each event by the duration returned by fn, events
+// are emitted in due order with ties kept in arrival order
+func (id *Observable) DelayWhen(fn func(interface{}) time.Duration) *Observable {
+	log.Println(id.UID, "Observable.DelayWhen")
+	return id.delayWith(fn)
 }
 
 // StartWith operator
@@ -74,3 +92,14 @@ func (id *Observable) StartWith(fn func() []interface{}) *Observable {
 	id.subscribeOps = append(id.subscribeOps, operator{operatorStartWith, fn})
 	return id
 }
+
+// EndWith operator
+// NOTE: Events are emitted ahead of completion through the operators chained after EndWith
+func (id *Observable) EndWith(fn func() []interface{}) *Observable {
+	log.Println(id.UID, "Observable.EndWith")
+	index := len(id.nextOps)
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		return index, fn()
+	}})
+	return id
+}
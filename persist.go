@@ -0,0 +1,340 @@
+package rx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Codec type, encodes the values of a persistent replay subject
+type Codec interface {
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// JSONCodec type, numbers decode as float64 and objects as map[string]interface{}
+type JSONCodec struct{}
+
+// Encode export
+func (id JSONCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Decode export
+func (id JSONCodec) Decode(data []byte) (interface{}, error) {
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// GobCodec type, concrete types other than the gob builtins must be
+// registered with gob.Register
+type GobCodec struct{}
+
+// Encode export
+func (id GobCodec) Encode(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(&value)
+	return buffer.Bytes(), err
+}
+
+// Decode export
+func (id GobCodec) Decode(data []byte) (interface{}, error) {
+	var value interface{}
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+	return value, err
+}
+
+// FsyncPolicy type
+type FsyncPolicy int
+
+// FsyncPolicy enum
+const (
+	// FsyncNever leaves flushing to the operating system
+	FsyncNever FsyncPolicy = iota
+	// FsyncAlways syncs after every record
+	FsyncAlways
+	// FsyncInterval syncs after a record once FsyncInterval has passed since the last sync
+	FsyncInterval
+)
+
+// PersistOptions type
+type PersistOptions struct {
+	// Codec encodes the values, JSONCodec when nil
+	Codec Codec
+	// MaxCount is the number of values replayed
+	MaxCount int
+	// MaxBytes bounds the encoded size of the values replayed, 0 is unbounded
+	MaxBytes int64
+	// Fsync is the durability of each record
+	Fsync         FsyncPolicy
+	FsyncInterval time.Duration
+}
+
+// errPersistRecord is returned when reading an incomplete or corrupt record
+var errPersistRecord = errors.New("rx: corrupt persisted record")
+
+// record header, payload length and crc32 of the payload
+const persistHeader = 8
+
+// NewPersistentReplaySubject init, replays the values persisted to path by
+// earlier runs and appends each new value to it. A truncated or corrupt tail
+// left by a crash is discarded on load, the file is compacted once it holds
+// twice the values or bytes replayed.
+func NewPersistentReplaySubject(path string, options PersistOptions) (*Observable, error) {
+	log.Println("Observable.NewSubject")
+	if options.MaxCount < 1 {
+		options.MaxCount = 1
+	}
+	if options.Codec == nil {
+		options.Codec = JSONCodec{}
+	}
+
+	id := NewObservable()
+	id.multicast = true
+	id.setReplay(options.MaxCount)
+
+	persist := &persistence{
+		path:    path,
+		options: options,
+		sizes:   []int{},
+	}
+	if err := persist.load(id.buffer); err != nil {
+		id.cancel()
+		return nil, err
+	}
+	id.persist = persist
+
+	go func() {
+		<-id.ctx.Done()
+		persist.close()
+	}()
+
+	return id, nil
+}
+
+type persistence struct {
+	mutex     sync.Mutex
+	path      string
+	file      *os.File
+	options   PersistOptions
+	sizes     []int
+	bytes     int64
+	records   int
+	fileBytes int64
+	synced    time.Time
+}
+
+// load reads the persisted records into buffer, truncating the file at the
+// first record that is incomplete or fails its checksum
+func (id *persistence) load(buffer *CircularBuffer) error {
+	file, err := os.OpenFile(id.path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	reader := bufio.NewReader(file)
+	offset := int64(0)
+	for {
+		payload, err := readRecord(reader, info.Size()-offset)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Println("Persist.load truncating", id.path, offset, err)
+			if err := file.Truncate(offset); err != nil {
+				file.Close()
+				return err
+			}
+			break
+		}
+		offset += int64(persistHeader + len(payload))
+		id.records++
+		value, err := id.options.Codec.Decode(payload)
+		if err != nil {
+			log.Println("Persist.load skipping", id.path, err)
+			continue
+		}
+		id.bound(buffer, len(payload))
+		buffer.Add(value)
+	}
+	id.fileBytes = offset
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	id.file = file
+	return nil
+}
+
+// bound evicts the oldest values so that a value of size fits the limits
+func (id *persistence) bound(buffer *CircularBuffer, size int) {
+	for id.options.MaxBytes > 0 && buffer.Length != 0 && id.bytes+int64(size) > id.options.MaxBytes {
+		buffer.Shift()
+		id.bytes -= int64(id.sizes[0])
+		id.sizes = id.sizes[1:]
+	}
+	if buffer.Length == buffer.Capacity {
+		// Add overwrites the oldest value
+		id.bytes -= int64(id.sizes[0])
+		id.sizes = id.sizes[1:]
+	}
+	id.sizes = append(id.sizes, size)
+	id.bytes += int64(size)
+}
+
+// add appends value to the file and buffer, a value failing to persist is
+// still replayed from memory
+func (id *persistence) add(buffer *CircularBuffer, value interface{}) {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+
+	payload, err := id.options.Codec.Encode(value)
+	if err != nil {
+		log.Println("Persist.add encode", id.path, err)
+		payload = nil
+	}
+	id.bound(buffer, len(payload))
+	buffer.Add(value)
+	if payload == nil || id.file == nil {
+		return
+	}
+
+	if err := id.write(payload); err != nil {
+		log.Println("Persist.add write", id.path, err)
+		return
+	}
+	if id.records > 2*buffer.Capacity || (id.options.MaxBytes > 0 && id.fileBytes > 2*id.options.MaxBytes) {
+		if err := id.compact(buffer); err != nil {
+			log.Println("Persist.add compact", id.path, err)
+		}
+	}
+}
+
+// write appends a record and syncs per the fsync policy
+func (id *persistence) write(payload []byte) error {
+	n, err := id.file.Write(encodeRecord(payload))
+	id.fileBytes += int64(n)
+	if err != nil {
+		return err
+	}
+	id.records++
+
+	switch id.options.Fsync {
+	case FsyncAlways:
+		return id.file.Sync()
+	case FsyncInterval:
+		if time.Since(id.synced) >= id.options.FsyncInterval {
+			id.synced = time.Now()
+			return id.file.Sync()
+		}
+		break
+	}
+	return nil
+}
+
+// compact rewrites the file with only the buffered values, the new file is
+// renamed into place so a crash leaves either the old or the new file
+func (id *persistence) compact(buffer *CircularBuffer) error {
+	log.Println("Persist.compact", id.path)
+	tmp := id.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	records := 0
+	size := int64(0)
+	writer := bufio.NewWriter(file)
+	for _, value := range buffer.Values() {
+		payload, err := id.options.Codec.Encode(value)
+		if err != nil {
+			continue
+		}
+		n, err := writer.Write(encodeRecord(payload))
+		if err != nil {
+			file.Close()
+			return err
+		}
+		records++
+		size += int64(n)
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := os.Rename(tmp, id.path); err != nil {
+		file.Close()
+		return err
+	}
+
+	id.file.Close()
+	id.file = file
+	id.records = records
+	id.fileBytes = size
+	return nil
+}
+
+// close syncs and closes the file, later values are only kept in memory
+func (id *persistence) close() {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	if id.file == nil {
+		return
+	}
+	id.file.Sync()
+	id.file.Close()
+	id.file = nil
+}
+
+// encodeRecord helper
+func encodeRecord(payload []byte) []byte {
+	record := make([]byte, persistHeader+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	copy(record[persistHeader:], payload)
+	return record
+}
+
+// readRecord helper, io.EOF only at a clean record boundary, a length beyond
+// the remaining bytes is corrupt rather than allocated
+func readRecord(reader io.Reader, remaining int64) ([]byte, error) {
+	header := make([]byte, persistHeader)
+	if n, err := io.ReadFull(reader, header); err != nil {
+		if err == io.EOF && n == 0 {
+			return nil, io.EOF
+		}
+		return nil, errPersistRecord
+	}
+	length := int64(binary.BigEndian.Uint32(header[0:4]))
+	if length > remaining-persistHeader {
+		return nil, errPersistRecord
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, errPersistRecord
+	}
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, errPersistRecord
+	}
+	return payload, nil
+}
@@ -0,0 +1,146 @@
+package rx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func persistValues(t *testing.T, path string, options PersistOptions, values ...interface{}) (*Observable, *Observer) {
+	subject, err := NewPersistentReplaySubject(path, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observer := NewObserver()
+	subject.Subscribe <- observer
+	for range subject.Values() {
+		<-observer.Event
+	}
+	for _, value := range values {
+		subject.Event <- Event{Type: EventTypeNext, Next: value}
+		<-observer.Event
+	}
+	return subject, observer
+}
+
+func persistClose(subject *Observable, observers ...*Observer) {
+	for _, observer := range observers {
+		observer.cancel()
+	}
+	subject.Event <- Event{Type: EventTypeComplete, Complete: subject}
+	<-subject.Finalize
+	subject.persist.close()
+}
+
+func TestPersistentReplaySubject(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.log")
+	options := PersistOptions{MaxCount: 3, Fsync: FsyncAlways}
+
+	subject, observer := persistValues(t, path, options, "a", "b", "c", "d")
+	persistClose(subject, observer)
+
+	subject, observer = persistValues(t, path, options)
+	if values := subject.Values(); !reflect.DeepEqual(values, []interface{}{"b", "c", "d"}) {
+		t.Fatalf("Expected values %v but got %v", []interface{}{"b", "c", "d"}, values)
+	}
+
+	// replayed to a new subscriber after the restart
+	late := NewObserver()
+	subject.Subscribe <- late
+	if event := <-late.Event; event.Next != "b" {
+		t.Fatalf("Expected replay of %v but got %v", "b", event.Next)
+	}
+	persistClose(subject, observer, late)
+}
+
+func TestPersistentReplaySubjectCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.log")
+	options := PersistOptions{MaxCount: 5, MaxBytes: 24, Codec: GobCodec{}}
+
+	subject, observer := persistValues(t, path, options, 1, 2, 3, 4, 5, 6, 7, 8)
+	if subject.persist.bytes > options.MaxBytes {
+		t.Fatalf("Expected at most %v bytes but got %v", options.MaxBytes, subject.persist.bytes)
+	}
+	if subject.persist.fileBytes > 2*options.MaxBytes {
+		t.Fatalf("Expected compaction to at most %v file bytes but got %v", 2*options.MaxBytes, subject.persist.fileBytes)
+	}
+	expected := subject.Values()
+	if len(expected) == 0 || len(expected) == options.MaxCount {
+		t.Fatalf("Expected values bounded by bytes but got %v", expected)
+	}
+	persistClose(subject, observer)
+
+	subject, observer = persistValues(t, path, options)
+	if values := subject.Values(); !reflect.DeepEqual(values, expected) {
+		t.Fatalf("Expected values %v but got %v", expected, values)
+	}
+	persistClose(subject, observer)
+}
+
+func TestPersistentReplaySubjectTruncatedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.log")
+	options := PersistOptions{MaxCount: 10}
+
+	subject, observer := persistValues(t, path, options, "a", "b")
+	persistClose(subject, observer)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a record cut short by a crash
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write(encodeRecord([]byte(`"c"`))[:6])
+	file.Close()
+
+	subject, observer = persistValues(t, path, options, "d")
+	if values := subject.Values(); !reflect.DeepEqual(values, []interface{}{"a", "b", "d"}) {
+		t.Fatalf("Expected values %v but got %v", []interface{}{"a", "b", "d"}, values)
+	}
+	if subject.persist.fileBytes <= info.Size() || subject.persist.records != 3 {
+		t.Fatalf("Expected the tail truncated ahead of the new record but got %v records", subject.persist.records)
+	}
+	persistClose(subject, observer)
+
+	subject, observer = persistValues(t, path, options)
+	if values := subject.Values(); !reflect.DeepEqual(values, []interface{}{"a", "b", "d"}) {
+		t.Fatalf("Expected values %v but got %v", []interface{}{"a", "b", "d"}, values)
+	}
+	persistClose(subject, observer)
+}
+
+func TestPersistentReplaySubjectOversized(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "replay.log")
+	options := PersistOptions{MaxCount: 2, MaxBytes: 10}
+	oversized := "aaaaaaaaaaaaaaaaaaaa"
+
+	// a value over MaxBytes arriving while the buffer is full
+	subject, observer := persistValues(t, path, options, "aa", "bb", oversized)
+	if values := subject.Values(); !reflect.DeepEqual(values, []interface{}{oversized}) {
+		t.Fatalf("Expected values %v but got %v", []interface{}{oversized}, values)
+	}
+	persistClose(subject, observer)
+
+	// the same records on reload, ahead of any compaction
+	file, err := os.OpenFile(path, os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{`"aa"`, `"bb"`, `"` + oversized + `"`} {
+		file.Write(encodeRecord([]byte(value)))
+	}
+	file.Close()
+
+	subject, observer = persistValues(t, path, options)
+	if values := subject.Values(); !reflect.DeepEqual(values, []interface{}{oversized}) {
+		t.Fatalf("Expected values %v but got %v", []interface{}{oversized}, values)
+	}
+	if len(subject.persist.sizes) != 1 {
+		t.Fatalf("Expected %v sizes but got %v", 1, len(subject.persist.sizes))
+	}
+	persistClose(subject, observer)
+}
@@ -0,0 +1,99 @@
+package rx
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy type, drives RetryWithPolicy and RepeatWithPolicy
+type RetryPolicy struct {
+	// MaxAttempts is the number of resubscriptions allowed, 0 is unlimited
+	MaxAttempts int
+	// InitialBackoff is the wait ahead of the first resubscription
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait, 0 is uncapped
+	MaxBackoff time.Duration
+	// Multiplier grows the wait on each attempt, below 1 the wait is constant
+	Multiplier float64
+	// Jitter randomizes the wait by up to the given fraction either way
+	Jitter float64
+	// Retryable returns false for errors that must not be retried, nil retries all
+	Retryable func(error) bool
+	// OnRetry is called with the attempt number and the error, nil on repeat,
+	// ahead of each wait
+	OnRetry func(attempt int, err error)
+}
+
+// Backoff returns the wait ahead of the given attempt, counted from 1
+func (id RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := id.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(id.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if id.MaxBackoff > 0 && backoff > float64(id.MaxBackoff) {
+		backoff = float64(id.MaxBackoff)
+	}
+	if id.Jitter > 0 {
+		backoff += backoff * id.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(backoff)
+}
+
+// next returns the wait ahead of attempt or false once the policy gives up
+func (id RetryPolicy) next(attempt int, err error) (time.Duration, bool) {
+	if id.MaxAttempts > 0 && attempt > id.MaxAttempts {
+		return 0, false
+	}
+	if err != nil && id.Retryable != nil && !id.Retryable(err) {
+		return 0, false
+	}
+	if id.OnRetry != nil {
+		id.OnRetry(attempt, err)
+	}
+	return id.Backoff(attempt), true
+}
+
+// RetryWithPolicy modifier, resubscribes on error after the policy backoff,
+// the wait runs on the Scheduler without blocking the Observable. The attempt
+// count starts over with the first event of a resubscription, so MaxAttempts
+// caps consecutive failures rather than failures over the whole lifetime.
+func (id *Observable) RetryWithPolicy(policy RetryPolicy) *Observable {
+	log.Println(id.UID, "Observable.RetryWithPolicy")
+	attempt := 0
+	id.retryPolicyFn = func(err error) (time.Duration, bool) {
+		attempt++
+		return policy.next(attempt, err)
+	}
+	id.Tap(func(event interface{}) {
+		attempt = 0
+	})
+	return id
+}
+
+// RepeatWithPolicy modifier, resubscribes on completion after the policy
+// backoff, the wait runs on the Scheduler without blocking the Observable.
+// Every completion counts as an attempt, MaxAttempts caps the repeats.
+func (id *Observable) RepeatWithPolicy(policy RetryPolicy) *Observable {
+	log.Println(id.UID, "Observable.RepeatWithPolicy")
+	attempt := 0
+	id.repeatPolicyFn = func(err error) (time.Duration, bool) {
+		attempt++
+		return policy.next(attempt, nil)
+	}
+	return id
+}
+
+// resubscribeAfter helper, resubscribes once d has elapsed
+func (id *Observable) resubscribeAfter(d time.Duration) {
+	if d <= 0 {
+		id.resubscribeFn(id)
+		return
+	}
+	id.after(d, func() bool {
+		dlog.Println(id.UID, "Observable.onResubscribe.backoff")
+		id.resubscribeFn(id)
+		return true
+	})
+}
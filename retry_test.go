@@ -0,0 +1,30 @@
+package rx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+		Multiplier:     2,
+	}
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, backoff := range expected {
+		if actual := policy.Backoff(i + 1); actual != backoff*time.Millisecond {
+			t.Fatalf("Expected attempt %v backoff %v but got %v", i+1, backoff*time.Millisecond, actual)
+		}
+	}
+
+	jittered := policy
+	jittered.Jitter = 0.5
+	for attempt := 1; attempt < 10; attempt++ {
+		base := policy.Backoff(attempt)
+		actual := jittered.Backoff(attempt)
+		if actual < base/2 || actual > base*3/2 {
+			t.Fatalf("Expected attempt %v backoff within %v of %v but got %v", attempt, jittered.Jitter, base, actual)
+		}
+	}
+}
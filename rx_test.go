@@ -1,8 +1,10 @@
 package rx
 
 import (
+	"context"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestMain(m *testing.M) {
@@ -91,3 +93,31 @@ loop:
 		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
 	}
 }
+
+func TestSubscribeContext(t *testing.T) {
+	events := 3
+
+	nextCnt := 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	observer := NewObserver()
+	interval := NewInterval(2)
+	interval.SubscribeContext(ctx, observer)
+	for nextCnt < events {
+		event := <-observer.Event
+		if event.Type != EventTypeNext {
+			t.Fatalf("Unexpected event type %v", event.Type)
+		}
+		nextCnt++
+	}
+	cancel()
+
+	select {
+	case <-interval.Finalize:
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected Finalize after context cancel")
+	}
+}
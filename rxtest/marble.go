@@ -0,0 +1,109 @@
+package rxtest
+
+import (
+	"errors"
+	"fmt"
+
+	rx "github.com/mlavergn/rxgo"
+)
+
+// ErrMarble is the error emitted by '#' when no error is provided
+var ErrMarble = errors.New("rxtest: marble error")
+
+// Notification type, an event at a virtual frame
+type Notification struct {
+	Frame int
+	Type  rx.EventType
+	Value interface{}
+	Error error
+}
+
+// String renders the Notification for test failures
+func (id Notification) String() string {
+	switch id.Type {
+	case rx.EventTypeNext:
+		return fmt.Sprintf("%d:%v", id.Frame, id.Value)
+	case rx.EventTypeError:
+		return fmt.Sprintf("%d:#(%v)", id.Frame, id.Error)
+	}
+	return fmt.Sprintf("%d:|", id.Frame)
+}
+
+// ParseMarble parses an ASCII marble diagram into Notifications
+//
+//	'-'   one frame of virtual time
+//	'a'   a next event, valued values["a"] or "a" when values has no entry
+//	'|'   completion
+//	'#'   error, emitting err or ErrMarble when err is nil
+//	'()'  groups events into a single frame, "(b|)"
+//	'^'   subscription point of a hot marble, it becomes frame 0
+//	' '   ignored
+//
+// Every character other than spaces and grouped events takes one frame. The
+// returned length is the number of frames covered by the diagram.
+func ParseMarble(marble string, values map[string]interface{}, err error) ([]Notification, int, error) {
+	if err == nil {
+		err = ErrMarble
+	}
+
+	notifications := []Notification{}
+	frame := 0
+	subscribe := -1
+	group := false
+	for i, char := range marble {
+		switch char {
+		case ' ':
+			continue
+		case '-':
+			break
+		case '^':
+			if subscribe != -1 || group {
+				return nil, 0, fmt.Errorf("rxtest: unexpected '^' at %d in %q", i, marble)
+			}
+			subscribe = frame
+			break
+		case '(':
+			if group {
+				return nil, 0, fmt.Errorf("rxtest: nested '(' at %d in %q", i, marble)
+			}
+			group = true
+			continue
+		case ')':
+			if !group {
+				return nil, 0, fmt.Errorf("rxtest: unexpected ')' at %d in %q", i, marble)
+			}
+			group = false
+			break
+		case '|':
+			notifications = append(notifications, Notification{Frame: frame, Type: rx.EventTypeComplete})
+			break
+		case '#':
+			notifications = append(notifications, Notification{Frame: frame, Type: rx.EventTypeError, Error: err})
+			break
+		default:
+			key := string(char)
+			var value interface{} = key
+			if v, ok := values[key]; ok {
+				value = v
+			}
+			notifications = append(notifications, Notification{Frame: frame, Type: rx.EventTypeNext, Value: value})
+			break
+		}
+		if !group {
+			frame++
+		}
+	}
+
+	if group {
+		return nil, 0, fmt.Errorf("rxtest: unterminated '(' in %q", marble)
+	}
+
+	if subscribe > 0 {
+		for i := range notifications {
+			notifications[i].Frame -= subscribe
+		}
+		frame -= subscribe
+	}
+
+	return notifications, frame, nil
+}
@@ -0,0 +1,245 @@
+package rxtest
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	rx "github.com/mlavergn/rxgo"
+)
+
+// Tester type, drives marble Observables on a shared virtual time Scheduler
+type Tester struct {
+	t         testing.TB
+	Scheduler *rx.TestScheduler
+	// Frame is the virtual duration of one marble character
+	Frame time.Duration
+	// Timeout bounds the real time Run waits for the Observables under test to
+	// handle what was sent to them before it fails the test
+	Timeout time.Duration
+	start   time.Time
+	frames  int
+	expects []*expectation
+}
+
+type expectation struct {
+	marble   string
+	expected []Notification
+	values   map[string]interface{}
+	mutex    sync.Mutex
+	actual   []Notification
+}
+
+// New init
+func New(t testing.TB) *Tester {
+	scheduler := rx.NewTestScheduler()
+	id := &Tester{
+		t:         t,
+		Scheduler: scheduler,
+		Frame:     10 * time.Millisecond,
+		Timeout:   5 * time.Second,
+		start:     scheduler.Now(),
+		frames:    0,
+		expects:   []*expectation{},
+	}
+	return id
+}
+
+// parse helper, fails the test on an invalid marble
+func (id *Tester) parse(marble string, values map[string]interface{}, err error) []Notification {
+	id.t.Helper()
+	notifications, frames, perr := ParseMarble(marble, values, err)
+	if perr != nil {
+		id.t.Fatal(perr)
+	}
+	if frames > id.frames {
+		id.frames = frames
+	}
+	return notifications
+}
+
+// at helper, the virtual time of a frame relative to the start of the test
+func (id *Tester) at(frame int) time.Duration {
+	return time.Duration(frame) * id.Frame
+}
+
+// schedule helper, emits notifications into observable relative to now
+func (id *Tester) schedule(observable *rx.Observable, notifications []Notification) {
+	for _, notification := range notifications {
+		if notification.Frame < 0 {
+			continue
+		}
+		var event rx.Event
+		switch notification.Type {
+		case rx.EventTypeNext:
+			event = rx.Event{Type: rx.EventTypeNext, Next: notification.Value}
+			break
+		case rx.EventTypeError:
+			event = rx.Event{Type: rx.EventTypeError, Error: notification.Error}
+			break
+		case rx.EventTypeComplete:
+			event = rx.Event{Type: rx.EventTypeComplete, Complete: observable}
+			break
+		}
+		id.Scheduler.Emit(id.at(notification.Frame), observable, event)
+	}
+}
+
+// Cold returns an Observable that emits marble relative to its subscription
+func (id *Tester) Cold(marble string, values map[string]interface{}, err error) *rx.Observable {
+	id.t.Helper()
+	notifications := id.parse(marble, values, err)
+	observable := rx.NewObservable().WithScheduler(id.Scheduler)
+	observable.UID = "cold." + observable.UID
+	observable.StartWith(func() []interface{} {
+		id.schedule(observable, notifications)
+		return nil
+	})
+	return observable
+}
+
+// Hot returns a Subject that emits marble relative to the start of the test,
+// events before the '^' subscription point are not emitted
+func (id *Tester) Hot(marble string, values map[string]interface{}, err error) *rx.Observable {
+	id.t.Helper()
+	notifications := id.parse(marble, values, err)
+	observable := rx.NewSubject().WithScheduler(id.Scheduler)
+	observable.UID = "hot." + observable.UID
+	id.schedule(observable, notifications)
+	return observable
+}
+
+// Expect subscribes to observable and asserts its output against marble once Run is called
+func (id *Tester) Expect(observable *rx.Observable, marble string, values map[string]interface{}, err error) {
+	id.t.Helper()
+	expect := &expectation{
+		marble:   marble,
+		expected: id.parse(marble, values, err),
+		values:   values,
+		actual:   []Notification{},
+	}
+	id.expects = append(id.expects, expect)
+
+	record := func(notification Notification) {
+		notification.Frame = int(id.Scheduler.Now().Sub(id.start) / id.Frame)
+		expect.mutex.Lock()
+		expect.actual = append(expect.actual, notification)
+		expect.mutex.Unlock()
+	}
+
+	observable.SubscribeFunc(func(next interface{}) {
+		record(Notification{Type: rx.EventTypeNext, Value: next})
+	}, func(err error) {
+		record(Notification{Type: rx.EventTypeError, Error: err})
+	}, func() {
+		record(Notification{Type: rx.EventTypeComplete})
+	})
+}
+
+// idle helper, the barrier between virtual time steps. Blocks until the
+// Observables on the Scheduler have handled every event, task and
+// subscription sent to them, at which point nothing can progress until the
+// virtual clock moves, and fails the test if that takes longer than Timeout.
+func (id *Tester) idle() {
+	id.t.Helper()
+	if !id.Scheduler.Wait(id.Timeout) {
+		id.t.Fatalf("rxtest: Observables still busy after %v", id.Timeout)
+	}
+}
+
+// Run advances virtual time timer by timer across all marbles, waiting for the
+// Observables to go idle after each step, then asserts every Expect
+func (id *Tester) Run() {
+	id.t.Helper()
+	id.idle()
+	for frame := 0; frame <= id.frames; frame++ {
+		at := id.start.Add(id.at(frame))
+		// timers due ahead of the frame fire one at a time so that timers
+		// they arm are registered before the clock moves past them
+		for next, ok := id.Scheduler.Next(); ok && next.Before(at); next, ok = id.Scheduler.Next() {
+			id.Scheduler.AdvanceTo(next)
+			id.idle()
+		}
+		id.Scheduler.AdvanceTo(at)
+		id.idle()
+	}
+
+	for _, expect := range id.expects {
+		expect.mutex.Lock()
+		actual := expect.actual
+		expect.mutex.Unlock()
+		if !equal(expect.expected, actual) {
+			id.t.Errorf("Expected %q %v but got %q %v", expect.marble, expect.expected, render(actual, expect.values), actual)
+		}
+	}
+}
+
+// equal helper
+func equal(expected []Notification, actual []Notification) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i := range expected {
+		e, a := expected[i], actual[i]
+		if e.Frame != a.Frame || e.Type != a.Type {
+			return false
+		}
+		if e.Type == rx.EventTypeNext && !reflect.DeepEqual(e.Value, a.Value) {
+			return false
+		}
+		if e.Type == rx.EventTypeError && !errors.Is(a.Error, e.Error) {
+			return false
+		}
+	}
+	return true
+}
+
+// render helper, draws notifications as a marble using the keys of values
+func render(notifications []Notification, values map[string]interface{}) string {
+	var builder strings.Builder
+	frame := 0
+	for i := 0; i < len(notifications); {
+		for frame < notifications[i].Frame {
+			builder.WriteByte('-')
+			frame++
+		}
+		j := i
+		for j < len(notifications) && notifications[j].Frame == notifications[i].Frame {
+			j++
+		}
+		if j-i > 1 {
+			builder.WriteByte('(')
+		}
+		for _, notification := range notifications[i:j] {
+			builder.WriteString(symbol(notification, values))
+		}
+		if j-i > 1 {
+			builder.WriteByte(')')
+		}
+		frame++
+		i = j
+	}
+	return builder.String()
+}
+
+// symbol helper
+func symbol(notification Notification, values map[string]interface{}) string {
+	switch notification.Type {
+	case rx.EventTypeError:
+		return "#"
+	case rx.EventTypeComplete:
+		return "|"
+	}
+	for key, value := range values {
+		if reflect.DeepEqual(value, notification.Value) {
+			return key
+		}
+	}
+	if value, ok := notification.Value.(string); ok && len(value) == 1 {
+		return value
+	}
+	return "?"
+}
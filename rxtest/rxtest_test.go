@@ -0,0 +1,101 @@
+package rxtest
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"testing"
+	"time"
+
+	rx "github.com/mlavergn/rxgo"
+)
+
+func TestParseMarble(t *testing.T) {
+	notifications, frames, err := ParseMarble("-a-(bc)-|", nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expect := []Notification{
+		{Frame: 1, Type: rx.EventTypeNext, Value: "a"},
+		{Frame: 3, Type: rx.EventTypeNext, Value: "b"},
+		{Frame: 3, Type: rx.EventTypeNext, Value: "c"},
+		{Frame: 5, Type: rx.EventTypeComplete},
+	}
+	if !equal(expect, notifications) {
+		t.Fatalf("Expected %v but got %v", expect, notifications)
+	}
+	if frames != 6 {
+		t.Fatalf("Expected frame count of %v but got %v", 6, frames)
+	}
+
+	notifications, _, err = ParseMarble("--^-a-#", map[string]interface{}{"a": 1}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	expect = []Notification{
+		{Frame: 2, Type: rx.EventTypeNext, Value: 1},
+		{Frame: 4, Type: rx.EventTypeError, Error: ErrMarble},
+	}
+	if !equal(expect, notifications) {
+		t.Fatalf("Expected %v but got %v", expect, notifications)
+	}
+
+	if _, _, err = ParseMarble("-(a-", nil, nil); err == nil {
+		t.Fatalf("Expected error for unterminated group")
+	}
+}
+
+func TestColdMap(t *testing.T) {
+	tester := New(t)
+	source := tester.Cold("-a-b-c-|", nil, nil)
+	source.Map(func(event interface{}) interface{} {
+		return strings.ToUpper(event.(string))
+	})
+	tester.Expect(source, "-A-B-C-|", nil, nil)
+	tester.Run()
+}
+
+func TestColdTake(t *testing.T) {
+	tester := New(t)
+	source := tester.Cold("-a-b-c-|", nil, nil).Take(2)
+	tester.Expect(source, "-a-(b|)", nil, nil)
+	tester.Run()
+}
+
+func TestHotError(t *testing.T) {
+	expect := errors.New("test")
+	values := map[string]interface{}{"a": 1, "b": 2}
+
+	tester := New(t)
+	source := tester.Hot("-a--b-#", values, expect)
+	source.Filter(func(event interface{}) bool {
+		return event.(int) > 1
+	})
+	tester.Expect(source, "----b-#", values, expect)
+	tester.Run()
+}
+
+func TestRunUnrelatedGoroutines(t *testing.T) {
+	// a signal handler and a busy goroutine outside the test never go idle
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+	stop := make(chan bool)
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}()
+
+	tester := New(t)
+	tester.Timeout = 1 * time.Second
+	source := tester.Cold("-a-b|", nil, nil).Delay(2 * tester.Frame)
+	tester.Expect(source, "---a-(b|)", nil, nil)
+	tester.Run()
+}
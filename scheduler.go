@@ -0,0 +1,373 @@
+package rx
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timer type
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// Ticker type
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Scheduler type, the source of time for time based sources and operators
+type Scheduler interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, fn func()) Timer
+}
+
+// DefaultScheduler is the real time Scheduler used by new Observables
+var DefaultScheduler Scheduler = realScheduler{}
+
+//
+// Real time
+//
+
+type realScheduler struct{}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (id realTimer) C() <-chan time.Time {
+	return id.timer.C
+}
+
+func (id realTimer) Stop() bool {
+	return id.timer.Stop()
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (id realTicker) C() <-chan time.Time {
+	return id.ticker.C
+}
+
+func (id realTicker) Stop() {
+	id.ticker.Stop()
+}
+
+func (id realScheduler) Now() time.Time {
+	return time.Now()
+}
+
+func (id realScheduler) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (id realScheduler) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (id realScheduler) AfterFunc(d time.Duration, fn func()) Timer {
+	return realTimer{time.AfterFunc(d, fn)}
+}
+
+//
+// Virtual time
+//
+
+// TestScheduler is a virtual time Scheduler, time only moves on Advance.
+// Observables using it account for the events, tasks and subscriptions sent
+// to them until handled, see Wait.
+type TestScheduler struct {
+	mutex  sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers []*testTimer
+	busy   int
+	quiet  *sync.Cond
+}
+
+type testTimer struct {
+	scheduler *TestScheduler
+	when      time.Time
+	period    time.Duration
+	seq       uint64
+	c         chan time.Time
+	fn        func()
+	stop      chan struct{}
+	active    bool
+}
+
+// NewTestScheduler init, the virtual clock starts at the Unix epoch
+func NewTestScheduler() *TestScheduler {
+	id := &TestScheduler{
+		now:    time.Unix(0, 0),
+		timers: []*testTimer{},
+	}
+	id.quiet = sync.NewCond(&id.mutex)
+	return id
+}
+
+func (id *TestScheduler) add(d time.Duration, period time.Duration, c chan time.Time, fn func()) *testTimer {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	id.seq++
+	timer := &testTimer{
+		scheduler: id,
+		when:      id.now.Add(d),
+		period:    period,
+		seq:       id.seq,
+		c:         c,
+		fn:        fn,
+		stop:      make(chan struct{}),
+		active:    true,
+	}
+	id.timers = append(id.timers, timer)
+	return timer
+}
+
+// Now returns the virtual time
+func (id *TestScheduler) Now() time.Time {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	return id.now
+}
+
+// NewTimer init
+func (id *TestScheduler) NewTimer(d time.Duration) Timer {
+	return id.add(d, 0, make(chan time.Time, 1), nil)
+}
+
+// NewTicker init, ticks are delivered unbuffered so Advance blocks until each
+// tick has been received, or the Ticker is stopped
+func (id *TestScheduler) NewTicker(d time.Duration) Ticker {
+	return testTicker{id.add(d, d, make(chan time.Time), nil)}
+}
+
+// AfterFunc init, fn is called synchronously by Advance
+func (id *TestScheduler) AfterFunc(d time.Duration, fn func()) Timer {
+	return id.add(d, 0, nil, fn)
+}
+
+// Pending returns the count of active timers and tickers
+func (id *TestScheduler) Pending() int {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	return len(id.timers)
+}
+
+// Next returns the virtual time the earliest active timer or ticker is due
+func (id *TestScheduler) Next() (time.Time, bool) {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	if len(id.timers) == 0 {
+		return time.Time{}, false
+	}
+	next := id.timers[0]
+	for _, timer := range id.timers[1:] {
+		if timer.when.Before(next.when) {
+			next = timer
+		}
+	}
+	return next.when, true
+}
+
+// Advance moves the virtual clock forward by d, firing due timers in order
+func (id *TestScheduler) Advance(d time.Duration) {
+	id.AdvanceTo(id.Now().Add(d))
+}
+
+// AdvanceTo moves the virtual clock forward to t, firing due timers in order
+func (id *TestScheduler) AdvanceTo(t time.Time) {
+	for {
+		id.mutex.Lock()
+		sort.Slice(id.timers, func(i, j int) bool {
+			if id.timers[i].when.Equal(id.timers[j].when) {
+				return id.timers[i].seq < id.timers[j].seq
+			}
+			return id.timers[i].when.Before(id.timers[j].when)
+		})
+		if len(id.timers) == 0 || id.timers[0].when.After(t) {
+			if id.now.Before(t) {
+				id.now = t
+			}
+			id.mutex.Unlock()
+			return
+		}
+		timer := id.timers[0]
+		id.now = timer.when
+		if timer.period > 0 {
+			id.seq++
+			timer.seq = id.seq
+			timer.when = timer.when.Add(timer.period)
+		} else {
+			id.timers = id.timers[1:]
+			timer.active = false
+		}
+		now := id.now
+		id.mutex.Unlock()
+
+		if timer.fn != nil {
+			timer.fn()
+			continue
+		}
+		// a tick is in flight until its reader has handled it
+		ticker := timer.period > 0
+		if ticker {
+			id.track(1)
+		}
+		select {
+		case timer.c <- now:
+			break
+		case <-timer.stop:
+			if ticker {
+				id.track(-1)
+			}
+			break
+		}
+	}
+}
+
+// Emit sends event to observable once d has elapsed, the event is in flight
+// for Wait until observable has handled it
+func (id *TestScheduler) Emit(d time.Duration, observable *Observable, event Event) Timer {
+	return id.AfterFunc(d, func() {
+		observable.emit(event)
+	})
+}
+
+// Wait blocks until the Observables using the scheduler have handled all the
+// events, tasks and subscriptions sent to them through rx, returns false once
+// timeout has passed first. Values sent by other means, e.g. directly to the
+// Event channel, are not accounted for.
+func (id *TestScheduler) Wait(timeout time.Duration) bool {
+	expired := false
+	deadline := time.AfterFunc(timeout, func() {
+		id.mutex.Lock()
+		expired = true
+		id.quiet.Broadcast()
+		id.mutex.Unlock()
+	})
+	defer deadline.Stop()
+
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	for id.busy != 0 && !expired {
+		id.quiet.Wait()
+	}
+	return id.busy == 0
+}
+
+// track helper, adds delta to the work in flight
+func (id *TestScheduler) track(delta int) {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	id.busy += delta
+	if id.busy == 0 {
+		id.quiet.Broadcast()
+	}
+}
+
+func (id *testTimer) C() <-chan time.Time {
+	return id.c
+}
+
+func (id *testTimer) Stop() bool {
+	scheduler := id.scheduler
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	if !id.active {
+		return false
+	}
+	id.active = false
+	close(id.stop)
+	for i, timer := range scheduler.timers {
+		if timer == id {
+			scheduler.timers = append(scheduler.timers[:i], scheduler.timers[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+type testTicker struct {
+	timer *testTimer
+}
+
+func (id testTicker) C() <-chan time.Time {
+	return id.timer.c
+}
+
+func (id testTicker) Stop() {
+	id.timer.Stop()
+}
+
+// ticked helper, releases a tick of a TestScheduler ticker once handled
+func ticked(scheduler Scheduler) {
+	if test, ok := scheduler.(*TestScheduler); ok {
+		test.track(-1)
+	}
+}
+
+//
+// Work in flight
+//
+
+// work type, counts the values sent to a goroutine and not yet handled on
+// behalf of a TestScheduler, without one it does nothing. Once sealed the
+// goroutine has gone and further values are not counted.
+type work struct {
+	mutex     sync.Mutex
+	scheduler *TestScheduler
+	count     int
+	sealed    bool
+}
+
+// track helper, counts on behalf of scheduler from now on
+func (id *work) track(scheduler Scheduler) {
+	test, _ := scheduler.(*TestScheduler)
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	if id.scheduler != nil && id.count != 0 {
+		id.scheduler.track(-id.count)
+	}
+	id.scheduler = test
+	id.count = 0
+}
+
+// add helper, called ahead of sending a value
+func (id *work) add() {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	if id.scheduler == nil || id.sealed {
+		return
+	}
+	id.count++
+	id.scheduler.track(1)
+}
+
+// done helper, called once a value has been handled or dropped
+func (id *work) done() {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	if id.count == 0 {
+		return
+	}
+	id.count--
+	id.scheduler.track(-1)
+}
+
+// seal helper, releases the values that will never be handled
+func (id *work) seal() {
+	id.mutex.Lock()
+	defer id.mutex.Unlock()
+	id.sealed = true
+	if id.count != 0 {
+		id.scheduler.track(-id.count)
+		id.count = 0
+	}
+}
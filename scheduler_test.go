@@ -0,0 +1,71 @@
+package rx
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSchedulerInterval(t *testing.T) {
+	events := 5
+
+	nextCnt := 0
+	completeCnt := 0
+
+	scheduler := NewTestScheduler()
+	interval := NewInterval(1000).WithScheduler(scheduler).Take(events)
+	sub := interval.SubscribeFunc(func(next interface{}) {
+		if next != nextCnt {
+			t.Errorf("Expected next value %v but got %v", nextCnt, next)
+		}
+		nextCnt++
+	}, nil, func() {
+		completeCnt++
+	})
+
+	// wait for the interval to connect and start its ticker
+	for scheduler.Pending() == 0 {
+		runtime.Gosched()
+	}
+	scheduler.Advance(time.Duration(events) * time.Second)
+	<-sub.Done()
+
+	if nextCnt != events {
+		t.Fatalf("Expected next count of %v but got %v", events, nextCnt)
+	}
+	if completeCnt != 1 {
+		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
+	}
+	if scheduler.Now() != time.Unix(int64(events), 0) {
+		t.Fatalf("Expected virtual time %v but got %v", time.Unix(int64(events), 0), scheduler.Now())
+	}
+}
+
+func TestSchedulerAfterFunc(t *testing.T) {
+	scheduler := NewTestScheduler()
+	fired := []int{}
+	scheduler.AfterFunc(2*time.Second, func() {
+		fired = append(fired, 2)
+	})
+	scheduler.AfterFunc(1*time.Second, func() {
+		fired = append(fired, 1)
+	})
+	stopped := scheduler.AfterFunc(1*time.Second, func() {
+		fired = append(fired, 0)
+	})
+	if !stopped.Stop() {
+		t.Fatalf("Expected Stop of a pending timer to return true")
+	}
+
+	scheduler.Advance(1500 * time.Millisecond)
+	if len(fired) != 1 || fired[0] != 1 {
+		t.Fatalf("Expected fired timers %v but got %v", []int{1}, fired)
+	}
+	scheduler.Advance(1 * time.Second)
+	if len(fired) != 2 || fired[1] != 2 {
+		t.Fatalf("Expected fired timers %v but got %v", []int{1, 2}, fired)
+	}
+	if scheduler.Pending() != 0 {
+		t.Fatalf("Expected no pending timers but got %v", scheduler.Pending())
+	}
+}
@@ -0,0 +1,82 @@
+package rx
+
+// Skip operator, drops the first count events
+func (id *Observable) Skip(count int) *Observable {
+	log.Println(id.UID, "Observable.Skip")
+
+	counter := count
+	id.Filter(func(event interface{}) bool {
+		if counter > 0 {
+			counter--
+			return false
+		}
+		return true
+	})
+
+	return id
+}
+
+// SkipWhile operator, drops events while cond returns true
+func (id *Observable) SkipWhile(cond func(interface{}) bool) *Observable {
+	log.Println(id.UID, "Observable.SkipWhile")
+
+	skipping := true
+	id.Filter(func(event interface{}) bool {
+		if skipping && cond(event) {
+			return false
+		}
+		skipping = false
+		return true
+	})
+
+	return id
+}
+
+// SkipUntil operator, drops events until observable emits
+func (id *Observable) SkipUntil(observable *Observable) *Observable {
+	log.Println(id.UID, "Observable.SkipUntil")
+
+	open := false
+	// subscribe on the Observable goroutine so sub is set ahead of the callbacks
+	id.post(func() bool {
+		var sub *Observer
+		sub = id.subscribeInner(observable, func(next interface{}) bool {
+			open = true
+			id.unsubscribeInner(sub)
+			return true
+		}, func(err error) bool {
+			return id.onError(err)
+		}, func() bool {
+			return true
+		})
+		return true
+	})
+
+	id.Filter(func(event interface{}) bool {
+		return open
+	})
+
+	return id
+}
+
+// SkipLast operator, drops the last count events by delaying each event until
+// count newer events have arrived
+func (id *Observable) SkipLast(count int) *Observable {
+	log.Println(id.UID, "Observable.SkipLast")
+	if count < 1 {
+		return id
+	}
+
+	buffer := NewCircularBuffer(count)
+	id.Map(func(event interface{}) interface{} {
+		if buffer.Length < buffer.Capacity {
+			buffer.Add(event)
+			return nil
+		}
+		_, oldest := buffer.Next(-1)
+		buffer.Add(event)
+		return oldest
+	})
+
+	return id
+}
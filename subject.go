@@ -1,9 +1,19 @@
 package rx
 
+import (
+	"context"
+	"time"
+)
+
 // NewSubject init
 func NewSubject() *Observable {
+	return NewSubjectContext(context.Background())
+}
+
+// NewSubjectContext init
+func NewSubjectContext(ctx context.Context) *Observable {
 	log.Println("Observable.NewSubject")
-	id := NewObservable()
+	id := NewObservableContext(ctx)
 	id.multicast = true
 
 	return id
@@ -28,3 +38,108 @@ func NewReplaySubject(bufferSize int) *Observable {
 
 	return id
 }
+
+// NewReplaySubjectWindow init, replays at most bufferSize values no older
+// than window according to the Scheduler clock
+func NewReplaySubjectWindow(bufferSize int, window time.Duration) *Observable {
+	log.Println("Observable.NewSubject")
+	id := NewObservable()
+	id.multicast = true
+	id.setReplayWindow(bufferSize, window)
+
+	return id
+}
+
+// NewAsyncSubject init, emits only the last value ahead of completion
+func NewAsyncSubject() *Observable {
+	log.Println("Observable.NewSubject")
+	id := NewObservable()
+	id.multicast = true
+	id.TakeLast(1)
+
+	return id
+}
+
+// Value returns the current value of a behavior subject or the most recent
+// value of a replay subject, safe to call from any goroutine
+func (id *Observable) Value() (interface{}, bool) {
+	if id.buffer == nil {
+		return nil, false
+	}
+	value, ok := id.buffer.Last()
+	if !ok || id.replayWindow <= 0 {
+		return value, ok
+	}
+	entry := value.(timestamped)
+	if id.replayStale(entry) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Values returns the values replayed to a new subscriber from oldest to
+// newest, safe to call from any goroutine
+func (id *Observable) Values() []interface{} {
+	if id.buffer == nil {
+		return []interface{}{}
+	}
+	return id.replayValues()
+}
+
+//
+// Replay buffer entries are timestamped when the subject has a replay window
+//
+
+type timestamped struct {
+	at    time.Time
+	value interface{}
+}
+
+// replayStale helper
+func (id *Observable) replayStale(entry timestamped) bool {
+	return id.scheduler.Now().Sub(entry.at) > id.replayWindow
+}
+
+// replayAdd helper, evicts the stale values ahead of adding event
+func (id *Observable) replayAdd(event interface{}) {
+	if id.persist != nil {
+		id.persist.add(id.buffer, event)
+		return
+	}
+	if id.replayWindow <= 0 {
+		id.buffer.Add(event)
+		return
+	}
+	id.replayEvict()
+	id.buffer.Add(timestamped{id.scheduler.Now(), event})
+}
+
+// replayEvict helper, removes the values older than the replay window
+func (id *Observable) replayEvict() {
+	if id.replayWindow <= 0 {
+		return
+	}
+	for {
+		value, ok := id.buffer.First()
+		if !ok || !id.replayStale(value.(timestamped)) {
+			return
+		}
+		id.buffer.Shift()
+	}
+}
+
+// replayValues helper, the buffered values within the replay window
+func (id *Observable) replayValues() []interface{} {
+	values := id.buffer.Values()
+	if id.replayWindow <= 0 {
+		return values
+	}
+	fresh := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		entry := value.(timestamped)
+		if !id.replayStale(entry) {
+			fresh = append(fresh, entry.value)
+		}
+	}
+	return fresh
+}
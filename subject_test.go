@@ -2,6 +2,7 @@ package rx
 
 import (
 	"testing"
+	"time"
 )
 
 func TestReplay(t *testing.T) {
@@ -58,3 +59,222 @@ loop:
 		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
 	}
 }
+
+func TestAsyncSubject(t *testing.T) {
+	nextCnt := 0
+	completeCnt := 0
+	var last interface{}
+
+	subject := NewAsyncSubject()
+	sub := subject.SubscribeFunc(func(next interface{}) {
+		last = next
+		nextCnt++
+	}, nil, func() {
+		completeCnt++
+	})
+	subject.Event <- Event{Type: EventTypeNext, Next: 1}
+	subject.Event <- Event{Type: EventTypeNext, Next: 2}
+	subject.Event <- Event{Type: EventTypeNext, Next: 3}
+	subject.Event <- Event{Type: EventTypeComplete, Complete: subject}
+	<-sub.Done()
+
+	if nextCnt != 1 || last != 3 {
+		t.Fatalf("Expected next count of %v with %v but got %v with %v", 1, 3, nextCnt, last)
+	}
+	if completeCnt != 1 {
+		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
+	}
+}
+
+func TestBehaviorSubjectValue(t *testing.T) {
+	subject := NewBehaviorSubject(99)
+	if value, ok := subject.Value(); !ok || value != 99 {
+		t.Fatalf("Expected value %v but got %v %v", 99, value, ok)
+	}
+
+	observer := NewObserver()
+	subject.Subscribe <- observer
+	<-observer.Event
+	subject.Event <- Event{Type: EventTypeNext, Next: 5}
+	<-observer.Event
+
+	if value, ok := subject.Value(); !ok || value != 5 {
+		t.Fatalf("Expected value %v but got %v %v", 5, value, ok)
+	}
+	if _, ok := NewSubject().Value(); ok {
+		t.Fatalf("Expected no value for a plain subject")
+	}
+}
+
+func TestReplaySubjectValues(t *testing.T) {
+	subject := NewReplaySubject(3)
+	observer := NewObserver()
+	subject.Subscribe <- observer
+	for i := 1; i <= 5; i++ {
+		subject.Event <- Event{Type: EventTypeNext, Next: i}
+		<-observer.Event
+	}
+
+	values := subject.Values()
+	if len(values) != 3 || values[0] != 3 || values[2] != 5 {
+		t.Fatalf("Expected values %v but got %v", []int{3, 4, 5}, values)
+	}
+	if value, ok := subject.Value(); !ok || value != 5 {
+		t.Fatalf("Expected value %v but got %v %v", 5, value, ok)
+	}
+}
+
+func TestReplaySubjectWindow(t *testing.T) {
+	scheduler := NewTestScheduler()
+	subject := NewReplaySubjectWindow(10, 1500*time.Millisecond).WithScheduler(scheduler)
+	observer := NewObserver()
+	subject.Subscribe <- observer
+	for i := 1; i <= 3; i++ {
+		subject.Event <- Event{Type: EventTypeNext, Next: i}
+		<-observer.Event
+		scheduler.Advance(1 * time.Second)
+	}
+
+	// 1 was added 3s ago and 2 was added 2s ago
+	values := subject.Values()
+	if len(values) != 1 || values[0] != 3 {
+		t.Fatalf("Expected values %v but got %v", []int{3}, values)
+	}
+
+	late := NewObserver()
+	subject.Subscribe <- late
+	event := <-late.Event
+	if event.Next != 3 {
+		t.Fatalf("Expected replay of %v but got %v", 3, event.Next)
+	}
+	if subject.buffer.Length != 1 {
+		t.Fatalf("Expected stale values evicted on replay but got length %v", subject.buffer.Length)
+	}
+
+	scheduler.Advance(1 * time.Second)
+	if value, ok := subject.Value(); ok {
+		t.Fatalf("Expected no value once the window has passed but got %v", value)
+	}
+}
+
+func TestRefCount(t *testing.T) {
+	piped := make(chan *Observable, 4)
+	subject := NewSubject()
+	subject.Resubscribe(func(observer *Observable) error {
+		source := NewSubject()
+		source.Pipe(observer)
+		piped <- source
+		return nil
+	})
+	subject.RefCount()
+
+	// not connected until the first subscriber
+	select {
+	case <-piped:
+		t.Fatalf("Expected no connection ahead of the first subscriber")
+	case <-time.After(10 * time.Millisecond):
+		break
+	}
+
+	for i := 1; i <= 2; i++ {
+		received := make(chan interface{}, 1)
+		sub := subject.SubscribeFunc(func(next interface{}) {
+			received <- next
+		}, nil, nil)
+		source := <-piped
+		source.Event <- Event{Type: EventTypeNext, Next: i}
+		if next := <-received; next != i {
+			t.Fatalf("Expected next value %v but got %v", i, next)
+		}
+
+		// the last unsubscribe tears down the upstream only
+		sub.Unsubscribe()
+		<-source.Finalize
+		select {
+		case <-subject.Finalize:
+			t.Fatalf("Expected ref counted subject to stay alive")
+		default:
+		}
+	}
+}
+
+func TestRefCountPipe(t *testing.T) {
+	source := NewSubject()
+	subject := NewSubject()
+	source.Pipe(subject)
+	subject.RefCount()
+
+	// without Resubscribe the last unsubscribe finalizes the subject and upstream
+	received := make(chan interface{}, 1)
+	sub := subject.SubscribeFunc(func(next interface{}) {
+		received <- next
+	}, nil, nil)
+	source.Event <- Event{Type: EventTypeNext, Next: 1}
+	if next := <-received; next != 1 {
+		t.Fatalf("Expected next value %v but got %v", 1, next)
+	}
+	sub.Unsubscribe()
+	for _, observable := range []*Observable{subject, source} {
+		select {
+		case <-observable.Finalize:
+			break
+		case <-time.After(1 * time.Second):
+			t.Fatalf("Expected %v to finalize after the last unsubscribe", observable.UID)
+		}
+	}
+}
+
+func TestRefCountSource(t *testing.T) {
+	interval := NewInterval(1).RefCount()
+	received := make(chan interface{}, 1)
+	sub := interval.SubscribeFunc(func(next interface{}) {
+		select {
+		case received <- next:
+			break
+		default:
+			break
+		}
+	}, nil, nil)
+	<-received
+	sub.Unsubscribe()
+	select {
+	case <-interval.Finalize:
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected the interval to finalize after the last unsubscribe")
+	}
+}
+
+func TestShareReplay(t *testing.T) {
+	subject := NewSubject().ShareReplay(2, true)
+	first := NewObserver()
+	subject.Subscribe <- first
+	for i := 1; i <= 3; i++ {
+		subject.Event <- Event{Type: EventTypeNext, Next: i}
+		<-first.Event
+	}
+
+	late := NewObserver()
+	subject.Subscribe <- late
+	for _, expected := range []int{2, 3} {
+		if event := <-late.Event; event.Next != expected {
+			t.Fatalf("Expected replay of %v but got %v", expected, event.Next)
+		}
+	}
+
+	// alive without observers and still replaying
+	late.cancel()
+	subject.Unsubscribe <- first
+	subject.Unsubscribe <- late
+	subject.Event <- Event{Type: EventTypeNext, Next: 4}
+	again := NewObserver()
+	subject.Subscribe <- again
+	// subscription may be served ahead of the event, 4 is then sent live
+	values := []interface{}{}
+	for len(values) == 0 || values[len(values)-1] != 4 {
+		values = append(values, (<-again.Event).Next)
+	}
+	if len(values) < 2 || values[len(values)-2] != 3 {
+		t.Fatalf("Expected values ending with %v but got %v", []int{3, 4}, values)
+	}
+}
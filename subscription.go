@@ -0,0 +1,111 @@
+package rx
+
+import (
+	"context"
+	"sync"
+)
+
+// Subscription type
+type Subscription struct {
+	UID      string
+	observer *Observer
+	cancel   context.CancelFunc
+	done     chan struct{}
+	errMutex sync.RWMutex
+	err      error
+}
+
+// SubscribeFunc subscribes callbacks to the Observable, any of the callbacks may be nil.
+// Callbacks are invoked from a single goroutine owned by the Subscription which exits
+// on completion, error or Unsubscribe. Callbacks may run before SubscribeFunc returns,
+// so a callback must not read the returned Subscription without synchronization,
+// limit the events with operators such as Take or TakeWhile instead.
+func (id *Observable) SubscribeFunc(onNext func(interface{}), onError func(error), onComplete func()) *Subscription {
+	log.Println(id.UID, "Observable.SubscribeFunc")
+	return id.subscribeFunc(context.Background(), onNext, onError, onComplete)
+}
+
+// subscribeFunc helper, the Subscription is unsubscribed when ctx is done,
+// used by operators to tie inner subscriptions to the Observable context
+func (id *Observable) subscribeFunc(ctx context.Context, onNext func(interface{}), onError func(error), onComplete func()) *Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+	observer := NewObserver()
+	observer.work.track(id.scheduler)
+	sub := &Subscription{
+		UID:      observer.UID,
+		observer: observer,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	// block to allow the dispatch goroutine to spin up
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer func() {
+			dlog.Println(sub.UID, "Subscription.Done")
+			observer.work.seal()
+			// releases the SubscribeContext watcher
+			cancel()
+			close(sub.done)
+		}()
+		wg.Done()
+		for {
+			select {
+			case event := <-observer.Event:
+				// no callback runs once unsubscribed, even for a pending event
+				if ctx.Err() != nil {
+					return
+				}
+				switch event.Type {
+				case EventTypeNext:
+					if onNext != nil {
+						onNext(event.Next)
+					}
+					observer.work.done()
+					break
+				case EventTypeError:
+					sub.errMutex.Lock()
+					sub.err = event.Error
+					sub.errMutex.Unlock()
+					if onError != nil {
+						onError(event.Error)
+					}
+					return
+				case EventTypeComplete:
+					if onComplete != nil {
+						onComplete()
+					}
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	id.SubscribeContext(ctx, observer)
+
+	return sub
+}
+
+// Unsubscribe stops delivery to the Subscription callbacks, it does not block
+// and is safe to call from within a callback
+func (id *Subscription) Unsubscribe() {
+	log.Println(id.UID, "Subscription.Unsubscribe")
+	id.cancel()
+}
+
+// Done is closed once the Subscription has completed, errored or been unsubscribed
+func (id *Subscription) Done() <-chan struct{} {
+	return id.done
+}
+
+// Err returns the error that terminated the Subscription, if any
+func (id *Subscription) Err() error {
+	id.errMutex.RLock()
+	defer id.errMutex.RUnlock()
+	return id.err
+}
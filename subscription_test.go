@@ -0,0 +1,89 @@
+package rx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubscribeFunc(t *testing.T) {
+	events := 5
+
+	nextCnt := 0
+	errorCnt := 0
+	completeCnt := 0
+
+	values := []interface{}{1, 2, 3, 4, 5}
+	sub := NewFrom(values).SubscribeFunc(func(next interface{}) {
+		nextCnt++
+	}, func(err error) {
+		errorCnt++
+	}, func() {
+		completeCnt++
+	})
+	<-sub.Done()
+
+	if sub.Err() != nil {
+		t.Fatalf("Unexpected error %v", sub.Err())
+	}
+	if nextCnt != events {
+		t.Fatalf("Expected next count of %v but got %v", events, nextCnt)
+	}
+	if errorCnt != 0 {
+		t.Fatalf("Expected error count of %v but got %v", 0, errorCnt)
+	}
+	if completeCnt != 1 {
+		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
+	}
+}
+
+func TestSubscribeFuncError(t *testing.T) {
+	expect := errors.New("test")
+
+	subject := NewSubject()
+	sub := subject.SubscribeFunc(nil, nil, nil)
+	subject.Event <- Event{Type: EventTypeError, Error: expect}
+	<-sub.Done()
+
+	if sub.Err() != expect {
+		t.Fatalf("Expected error %v but got %v", expect, sub.Err())
+	}
+}
+
+func TestSubscriptionUnsubscribe(t *testing.T) {
+	events := 3
+
+	nextCnt := 0
+
+	interval := NewInterval(2)
+	received := make(chan bool)
+	unsubscribed := make(chan bool)
+	sub := interval.SubscribeFunc(func(next interface{}) {
+		nextCnt++
+		if nextCnt == events {
+			received <- true
+			<-unsubscribed
+		}
+	}, nil, nil)
+	<-received
+	sub.Unsubscribe()
+	close(unsubscribed)
+
+	select {
+	case <-sub.Done():
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected Done after Unsubscribe")
+	}
+
+	select {
+	case <-interval.Finalize:
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected Finalize after Unsubscribe")
+	}
+
+	if nextCnt != events {
+		t.Fatalf("Expected next count of %v but got %v", events, nextCnt)
+	}
+}
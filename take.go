@@ -1,26 +1,49 @@
 package rx
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNoElements is emitted when the Observable completes without the element
+// required by First, Last, ElementAt or Single
+var ErrNoElements = errors.New("rx: no elements in sequence")
+
+// ErrTooManyElements is emitted by Single when more than one element is seen
+var ErrTooManyElements = errors.New("rx: more than one element in sequence")
+
+// ErrArgumentOutOfRange is emitted by ElementAt for a negative position
+var ErrArgumentOutOfRange = errors.New("rx: argument out of range")
 
 // Take export
+// Take style operators are positional, each limits the events reaching the
+// operators chained after it so that several may be stacked
 func (id *Observable) Take(count int) *Observable {
 	log.Println(id.UID, "Observable.Take")
 
 	counter := count
-	id.takeFn = func() bool {
+	id.nextOps = append(id.nextOps, operator{operatorTake, func(event interface{}) (bool, bool) {
 		counter--
 		dlog.Println(id.UID, "Observable.Take state", (counter > 0), counter)
-		return (counter > 0)
-	}
+		return (counter >= 0), (counter > 0)
+	}})
 
 	return id
 }
 
 // TakeWhile export
-func (id *Observable) TakeWhile(cond func() bool) *Observable {
+// Emits events while cond returns true for the event and then completes, with
+// inclusive the event failing cond is emitted ahead of completion
+func (id *Observable) TakeWhile(cond func(interface{}) bool, inclusive bool) *Observable {
 	log.Println(id.UID, "Observable.TakeWhile")
 
-	id.takeFn = cond
+	id.nextOps = append(id.nextOps, operator{operatorTake, func(event interface{}) (bool, bool) {
+		if cond(event) {
+			return true, true
+		}
+		return inclusive, false
+	}})
 
 	return id
 }
@@ -30,16 +53,19 @@ func (id *Observable) TakeUntil(observable *Observable) *Observable {
 	log.Println(id.UID, "Observable.TakeUntil")
 
 	watcher := NewObserver()
+	watcher.work.track(observable.scheduler)
 	var wg sync.WaitGroup
 	wg.Add(1)
 
 	go func() {
 		wg.Done()
-		defer func() { id.Event <- Event{Type: EventTypeComplete, Complete: id} }()
+		defer watcher.work.seal()
 		<-watcher.Event
+		id.emit(Event{Type: EventTypeComplete, Complete: id})
 	}()
 
 	wg.Wait()
+	observable.pending.add()
 	observable.Subscribe <- watcher
 
 	return id
@@ -63,6 +89,168 @@ func (id *Observable) TakeUntilClose(close CloseCh) *Observable {
 	return id
 }
 
+// TakeUntilContext export
+func (id *Observable) TakeUntilContext(ctx context.Context) *Observable {
+	log.Println(id.UID, "Observable.TakeUntilContext")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		wg.Done()
+		select {
+		case <-ctx.Done():
+			id.emit(Event{Type: EventTypeComplete, Complete: id})
+			break
+		case <-id.ctx.Done():
+			break
+		}
+	}()
+
+	wg.Wait()
+
+	return id
+}
+
+// TakeLast operator, emits the last count events on completion
+func (id *Observable) TakeLast(count int) *Observable {
+	log.Println(id.UID, "Observable.TakeLast")
+	index := len(id.nextOps)
+	var buffer *CircularBuffer
+	if count > 0 {
+		buffer = NewCircularBuffer(count)
+	}
+
+	id.Filter(func(event interface{}) bool {
+		if buffer != nil {
+			buffer.Add(event)
+		}
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		if buffer == nil {
+			return index + 1, nil
+		}
+		return index + 1, buffer.Values()
+	}})
+
+	return id
+}
+
+// First operator, emits the first event passing cond then completes, a nil
+// cond passes every event. Errors with ErrNoElements if none is found.
+func (id *Observable) First(cond func(interface{}) bool) *Observable {
+	log.Println(id.UID, "Observable.First")
+
+	found := false
+	id.nextOps = append(id.nextOps, operator{operatorTake, func(event interface{}) (bool, bool) {
+		if found || (cond != nil && !cond(event)) {
+			return false, !found
+		}
+		found = true
+		return true, false
+	}})
+
+	id.completeOps = append(id.completeOps, operator{operatorCheck, func() error {
+		if !found {
+			return ErrNoElements
+		}
+		return nil
+	}})
+
+	return id
+}
+
+// Last operator, emits the last event passing cond on completion, a nil cond
+// passes every event. Errors with ErrNoElements if none is found.
+func (id *Observable) Last(cond func(interface{}) bool) *Observable {
+	log.Println(id.UID, "Observable.Last")
+	index := len(id.nextOps)
+
+	var last interface{}
+	found := false
+	id.Filter(func(event interface{}) bool {
+		if cond == nil || cond(event) {
+			last = event
+			found = true
+		}
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorCheck, func() error {
+		if !found {
+			return ErrNoElements
+		}
+		return nil
+	}}, operator{operatorFlush, func() (int, []interface{}) {
+		return index + 1, []interface{}{last}
+	}})
+
+	return id
+}
+
+// ElementAt operator, emits the event at the zero based position then
+// completes. Errors with ErrNoElements if the Observable completes first and
+// with ErrArgumentOutOfRange on the first event for a negative position.
+func (id *Observable) ElementAt(position int) *Observable {
+	log.Println(id.UID, "Observable.ElementAt")
+
+	counter := 0
+	id.nextOps = append(id.nextOps, operator{operatorTake, func(event interface{}) (bool, bool) {
+		counter++
+		if counter-1 < position {
+			return false, true
+		}
+		return counter-1 == position, false
+	}})
+
+	id.completeOps = append(id.completeOps, operator{operatorCheck, func() error {
+		if position < 0 {
+			return ErrArgumentOutOfRange
+		}
+		if counter <= position {
+			return ErrNoElements
+		}
+		return nil
+	}})
+
+	return id
+}
+
+// Single operator, emits the only event on completion. Errors with
+// ErrNoElements if there is none or ErrTooManyElements as soon as a second
+// event arrives.
+func (id *Observable) Single() *Observable {
+	log.Println(id.UID, "Observable.Single")
+	index := len(id.nextOps)
+
+	var single interface{}
+	counter := 0
+	id.nextOps = append(id.nextOps, operator{operatorTake, func(event interface{}) (bool, bool) {
+		counter++
+		if counter == 1 {
+			single = event
+			return false, true
+		}
+		return false, false
+	}})
+
+	id.completeOps = append(id.completeOps, operator{operatorCheck, func() error {
+		switch {
+		case counter == 0:
+			return ErrNoElements
+		case counter > 1:
+			return ErrTooManyElements
+		}
+		return nil
+	}}, operator{operatorFlush, func() (int, []interface{}) {
+		return index + 1, []interface{}{single}
+	}})
+
+	return id
+}
+
 //
 // TakeUntil(close) is a common pattern, this basic channel based close keeps use consistent
 //
@@ -0,0 +1,240 @@
+package rx
+
+import (
+	"time"
+)
+
+// Debounce operator, emits an event only once d has passed without another event
+func (id *Observable) Debounce(d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.Debounce")
+	index := len(id.nextOps)
+	var latest interface{}
+	pending := false
+	generation := 0
+	var timer Timer
+
+	id.Filter(func(event interface{}) bool {
+		latest = event
+		pending = true
+		generation++
+		if timer != nil {
+			timer.Stop()
+		}
+		current := generation
+		timer = id.after(d, func() bool {
+			if current != generation || !pending {
+				return true
+			}
+			pending = false
+			timer = nil
+			return id.onNextFrom(latest, index+1)
+		})
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		generation++
+		if timer != nil {
+			timer.Stop()
+		}
+		if !pending {
+			return index + 1, nil
+		}
+		pending = false
+		return index + 1, []interface{}{latest}
+	}}, operator{operatorError, func(err error) {
+		generation++
+		if timer != nil {
+			timer.Stop()
+		}
+	}})
+
+	return id
+}
+
+// ThrottleTime operator, emits at most one event per d. With leading the first
+// event of a window is emitted, with trailing the last event of the window is
+// emitted as it closes and opens the next window.
+func (id *Observable) ThrottleTime(d time.Duration, leading bool, trailing bool) *Observable {
+	log.Println(id.UID, "Observable.ThrottleTime")
+	index := len(id.nextOps)
+	var latest interface{}
+	pending := false
+	throttling := false
+	var timer Timer
+
+	var throttle func()
+	throttle = func() {
+		throttling = true
+		timer = id.after(d, func() bool {
+			timer = nil
+			throttling = false
+			if trailing && pending {
+				pending = false
+				throttle()
+				return id.onNextFrom(latest, index+1)
+			}
+			return true
+		})
+	}
+
+	id.Filter(func(event interface{}) bool {
+		if throttling {
+			latest = event
+			pending = trailing
+			return false
+		}
+		throttle()
+		if leading {
+			return true
+		}
+		latest = event
+		pending = trailing
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		if timer != nil {
+			timer.Stop()
+		}
+		if !pending {
+			return index + 1, nil
+		}
+		pending = false
+		return index + 1, []interface{}{latest}
+	}}, operator{operatorError, func(err error) {
+		if timer != nil {
+			timer.Stop()
+		}
+	}})
+
+	return id
+}
+
+// AuditTime operator, on an event waits d then emits the most recent event
+func (id *Observable) AuditTime(d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.AuditTime")
+	index := len(id.nextOps)
+	var latest interface{}
+	var timer Timer
+
+	id.Filter(func(event interface{}) bool {
+		latest = event
+		if timer == nil {
+			timer = id.after(d, func() bool {
+				if timer == nil {
+					return true
+				}
+				timer = nil
+				return id.onNextFrom(latest, index+1)
+			})
+		}
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		if timer == nil {
+			return index + 1, nil
+		}
+		timer.Stop()
+		timer = nil
+		return index + 1, []interface{}{latest}
+	}}, operator{operatorError, func(err error) {
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+	}})
+
+	return id
+}
+
+// Sample operator, emits the most recent event every d when it has changed.
+// Sampling runs at a fixed period from the first subscription.
+func (id *Observable) Sample(d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.Sample")
+	index := len(id.nextOps)
+	var latest interface{}
+	pending := false
+	var ticker Ticker
+
+	sample := func() bool {
+		if !pending {
+			return true
+		}
+		pending = false
+		return id.onNextFrom(latest, index+1)
+	}
+
+	stop := func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}
+
+	id.subscribeOps = append(id.subscribeOps, operator{operatorStartWith, func() []interface{} {
+		if ticker != nil {
+			return nil
+		}
+		ticker = id.scheduler.NewTicker(d)
+		tick := ticker.C()
+		go func() {
+			for {
+				select {
+				case <-tick:
+					ok := id.post(sample)
+					ticked(id.scheduler)
+					if !ok {
+						return
+					}
+					break
+				case <-id.ctx.Done():
+					return
+				}
+			}
+		}()
+		return nil
+	}})
+
+	id.Filter(func(event interface{}) bool {
+		latest = event
+		pending = true
+		return false
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		stop()
+		return index + 1, nil
+	}}, operator{operatorError, func(err error) {
+		stop()
+	}})
+
+	return id
+}
+
+// SampleWith operator, emits the most recent event when notifier emits and
+// the event has changed
+func (id *Observable) SampleWith(notifier *Observable) *Observable {
+	log.Println(id.UID, "Observable.SampleWith")
+	index := len(id.nextOps)
+	var latest interface{}
+	pending := false
+
+	id.Filter(func(event interface{}) bool {
+		latest = event
+		pending = true
+		return false
+	})
+
+	notifier.subscribeFunc(id.ctx, func(next interface{}) {
+		id.post(func() bool {
+			if !pending {
+				return true
+			}
+			pending = false
+			return id.onNextFrom(latest, index+1)
+		})
+	}, nil, nil)
+
+	return id
+}
@@ -0,0 +1,126 @@
+package rx
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTimeout is emitted by Timeout and TimeoutFirst when no event arrives in time
+var ErrTimeout = errors.New("rx: timeout")
+
+// Timeout operator, errors with ErrTimeout when d passes after subscription
+// or the previous event without an event. Composes with RetryWhen, the timer
+// is restarted once the Observable has resubscribed.
+func (id *Observable) Timeout(d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.Timeout")
+	return id.timeoutWith(d, false, nil)
+}
+
+// TimeoutFirst operator, errors with ErrTimeout when d passes after
+// subscription without a first event
+func (id *Observable) TimeoutFirst(d time.Duration) *Observable {
+	log.Println(id.UID, "Observable.TimeoutFirst")
+	return id.timeoutWith(d, true, nil)
+}
+
+// TimeoutWith operator, switches to fallback when d passes after subscription
+// or the previous event without an event. On the switch the upstream pipes are
+// unsubscribed and any further event of the upstream, including errors and
+// completion, is dropped so that id mirrors fallback. Composes with RetryWhen,
+// an error of fallback resubscribes to the upstream and restarts the timer.
+func (id *Observable) TimeoutWith(d time.Duration, fallback *Observable) *Observable {
+	log.Println(id.UID, "Observable.TimeoutWith")
+	return id.timeoutWith(d, false, fallback)
+}
+
+// timeoutWith helper
+func (id *Observable) timeoutWith(d time.Duration, first bool, fallback *Observable) *Observable {
+	index := len(id.nextOps)
+	seen := false
+	switched := false
+	active := false
+	generation := 0
+	var timer Timer
+
+	stop := func() {
+		generation++
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+	}
+
+	var arm func()
+	arm = func() {
+		stop()
+		current := generation
+		timer = id.after(d, func() bool {
+			if current != generation {
+				return true
+			}
+			timer = nil
+			if fallback == nil {
+				if !id.onError(ErrTimeout) {
+					return false
+				}
+				// resubscribed by RetryWhen or caught by CatchError
+				seen = false
+				arm()
+				return true
+			}
+			switched = true
+			active = true
+			id.detach()
+			id.subscribeInner(fallback, func(next interface{}) bool {
+				return id.onNextFrom(next, index+1)
+			}, func(err error) bool {
+				active = false
+				if !id.onError(err) {
+					return false
+				}
+				if !id.detached {
+					// resubscribed by RetryWhen, watch the new upstream
+					switched = false
+					seen = false
+					arm()
+				}
+				return true
+			}, func() bool {
+				active = false
+				return id.onComplete(id)
+			})
+			return true
+		})
+	}
+
+	id.subscribeOps = append(id.subscribeOps, operator{operatorStartWith, func() []interface{} {
+		if timer == nil && !switched && !(first && seen) {
+			arm()
+		}
+		return nil
+	}})
+
+	id.Filter(func(event interface{}) bool {
+		if switched {
+			return false
+		}
+		if !first {
+			arm()
+		} else if !seen {
+			stop()
+		}
+		seen = true
+		return true
+	})
+
+	id.completeOps = append(id.completeOps, operator{operatorFlush, func() (int, []interface{}) {
+		stop()
+		return index + 1, nil
+	}}, operator{operatorHold, func() bool {
+		return active
+	}}, operator{operatorError, func(err error) {
+		stop()
+	}})
+
+	return id
+}
@@ -0,0 +1,232 @@
+package typed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	rx "github.com/mlavergn/rxgo"
+)
+
+// ErrType is returned when an untyped event cannot be converted to the
+// type of the typed Observer receiving it
+var ErrType = errors.New("typed: unexpected event type")
+
+// Event type
+type Event[T any] struct {
+	Type  rx.EventType
+	Next  T
+	Error error
+}
+
+// Observer type
+type Observer[T any] struct {
+	Event    chan Event[T]
+	UID      string
+	observer *rx.Observer
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// NewObserver init
+func NewObserver[T any]() *Observer[T] {
+	observer := rx.NewObserver()
+	ctx, cancel := context.WithCancel(context.Background())
+	id := &Observer[T]{
+		Event:    make(chan Event[T], 1),
+		UID:      observer.UID,
+		observer: observer,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	// block to allow the reader goroutine to spin up
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer func() {
+			// unsubscribe the untyped Observer so the source never blocks
+			// on an Observer nobody reads, e.g. after a type mismatch
+			cancel()
+			close(id.Event)
+		}()
+		wg.Done()
+		for {
+			select {
+			case event, ok := <-observer.Event:
+				if !ok {
+					return
+				}
+				switch event.Type {
+				case rx.EventTypeNext:
+					next, ok := event.Next.(T)
+					if !ok {
+						var expect T
+						id.send(Event[T]{Type: rx.EventTypeError, Error: fmt.Errorf("%w: %T is not %T", ErrType, event.Next, expect)})
+						return
+					}
+					if !id.send(Event[T]{Type: rx.EventTypeNext, Next: next}) {
+						return
+					}
+					break
+				case rx.EventTypeError:
+					id.send(Event[T]{Type: rx.EventTypeError, Error: event.Error})
+					return
+				case rx.EventTypeComplete:
+					id.send(Event[T]{Type: rx.EventTypeComplete})
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	return id
+}
+
+// send helper, delivers event unless id was unsubscribed
+func (id *Observer[T]) send(event Event[T]) bool {
+	select {
+	case id.Event <- event:
+		return true
+	case <-id.ctx.Done():
+		return false
+	}
+}
+
+// Observer returns the untyped Observer backing id
+func (id *Observer[T]) Observer() *rx.Observer {
+	return id.observer
+}
+
+// Observable type
+type Observable[T any] struct {
+	UID        string
+	observable *rx.Observable
+}
+
+// FromObservable wraps an untyped Observable, events that are not of type T
+// are delivered to typed Observers as an ErrType error
+func FromObservable[T any](observable *rx.Observable) *Observable[T] {
+	return &Observable[T]{
+		UID:        observable.UID,
+		observable: observable,
+	}
+}
+
+// NewSubject init
+func NewSubject[T any]() *Observable[T] {
+	return FromObservable[T](rx.NewSubject())
+}
+
+// NewBehaviorSubject init
+func NewBehaviorSubject[T any](value T) *Observable[T] {
+	return FromObservable[T](rx.NewBehaviorSubject(value))
+}
+
+// NewReplaySubject init
+func NewReplaySubject[T any](bufferSize int) *Observable[T] {
+	return FromObservable[T](rx.NewReplaySubject(bufferSize))
+}
+
+// NewInterval init
+func NewInterval(msec int) *Observable[int] {
+	return FromObservable[int](rx.NewInterval(msec))
+}
+
+// NewFrom init
+func NewFrom[T any](values ...T) *Observable[T] {
+	events := make([]interface{}, len(values))
+	for i, value := range values {
+		events[i] = value
+	}
+	return FromObservable[T](rx.NewFrom(events))
+}
+
+// Observable returns the untyped Observable backing id
+func (id *Observable[T]) Observable() *rx.Observable {
+	return id.observable
+}
+
+// Subscribe adds observer to id, observer is unsubscribed once it has
+// received a terminal event
+func (id *Observable[T]) Subscribe(observer *Observer[T]) *Observable[T] {
+	id.observable.SubscribeContext(observer.ctx, observer.observer)
+	return id
+}
+
+// Unsubscribe removes observer from id, the Event channel of observer is
+// closed once its reader stops
+func (id *Observable[T]) Unsubscribe(observer *Observer[T]) *Observable[T] {
+	// the context of Subscribe unsubscribes the untyped Observer
+	observer.cancel()
+	return id
+}
+
+// Next sends value to the Observable
+func (id *Observable[T]) Next(value T) *Observable[T] {
+	id.observable.Event <- rx.Event{Type: rx.EventTypeNext, Next: value}
+	return id
+}
+
+// Error sends err to the Observable
+func (id *Observable[T]) Error(err error) *Observable[T] {
+	id.observable.Event <- rx.Event{Type: rx.EventTypeError, Error: err}
+	return id
+}
+
+// Complete completes the Observable
+func (id *Observable[T]) Complete() *Observable[T] {
+	id.observable.Event <- rx.Event{Type: rx.EventTypeComplete, Complete: id.observable}
+	return id
+}
+
+// Take operator
+func (id *Observable[T]) Take(count int) *Observable[T] {
+	id.observable.Take(count)
+	return id
+}
+
+// Filter export
+// Emit values that PASS (return true) for the filter condition
+func Filter[T any](observable *Observable[T], fn func(T) bool) *Observable[T] {
+	observable.observable.Filter(func(event interface{}) bool {
+		value, ok := event.(T)
+		if !ok {
+			// pass through so the Observer reports the mismatch
+			return true
+		}
+		return fn(value)
+	})
+	return observable
+}
+
+// Map modifies the event type
+// NOTE: like the untyped operators, Map modifies the underlying Observable,
+// so the source *Observable[T] must not be used after the call
+func Map[T, U any](observable *Observable[T], fn func(T) U) *Observable[U] {
+	observable.observable.Map(func(event interface{}) interface{} {
+		value, ok := event.(T)
+		if !ok {
+			// pass through so the Observer reports the mismatch
+			return event
+		}
+		return fn(value)
+	})
+	return FromObservable[U](observable.observable)
+}
+
+// Tap adds a side effect to an event
+func Tap[T any](observable *Observable[T], fn func(T)) *Observable[T] {
+	observable.observable.Tap(func(event interface{}) {
+		if value, ok := event.(T); ok {
+			fn(value)
+		}
+	})
+	return observable
+}
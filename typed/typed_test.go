@@ -0,0 +1,121 @@
+package typed
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	rx "github.com/mlavergn/rxgo"
+)
+
+func TestTypedMap(t *testing.T) {
+	expect := []string{"20", "40"}
+	actual := []string{}
+
+	errorCnt := 0
+	completeCnt := 0
+
+	observer := NewObserver[string]()
+	numbers := NewFrom(1, 2, 3, 4)
+	numbers = Filter(numbers, func(value int) bool {
+		return value%2 == 0
+	})
+	Map(numbers, func(value int) string {
+		return strconv.Itoa(value * 10)
+	}).Subscribe(observer)
+loop:
+	for {
+		select {
+		case event := <-observer.Event:
+			switch event.Type {
+			case rx.EventTypeNext:
+				actual = append(actual, event.Next)
+				break
+			case rx.EventTypeError:
+				errorCnt++
+				break loop
+			case rx.EventTypeComplete:
+				completeCnt++
+				break loop
+			}
+		}
+	}
+
+	if len(actual) != len(expect) {
+		t.Fatalf("Expected next values %v but got %v", expect, actual)
+	}
+	for i := range expect {
+		if actual[i] != expect[i] {
+			t.Fatalf("Expected next values %v but got %v", expect, actual)
+		}
+	}
+	if errorCnt != 0 {
+		t.Fatalf("Expected error count of %v but got %v", 0, errorCnt)
+	}
+	if completeCnt != 1 {
+		t.Fatalf("Expected complete count of %v but got %v", 1, completeCnt)
+	}
+}
+
+func TestTypedMismatch(t *testing.T) {
+	observer := NewObserver[int]()
+	FromObservable[int](rx.NewFrom([]interface{}{"one"})).Subscribe(observer)
+
+	event := <-observer.Event
+	if event.Type != rx.EventTypeError {
+		t.Fatalf("Expected error event but got %v", event.Type)
+	}
+	if !errors.Is(event.Error, ErrType) {
+		t.Fatalf("Expected error %v but got %v", ErrType, event.Error)
+	}
+}
+
+func TestTypedMismatchUnsubscribes(t *testing.T) {
+	observer := NewObserver[int]()
+	subject := rx.NewSubject()
+	FromObservable[int](subject).Subscribe(observer)
+	subject.Event <- rx.Event{Type: rx.EventTypeNext, Next: "one"}
+
+	event := <-observer.Event
+	if !errors.Is(event.Error, ErrType) {
+		t.Fatalf("Expected error %v but got %v", ErrType, event.Error)
+	}
+
+	// the upstream must not block on the abandoned untyped Observer
+	select {
+	case <-subject.Finalize:
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected upstream Finalize after type mismatch")
+	}
+}
+
+func TestTypedUnsubscribe(t *testing.T) {
+	observer := NewObserver[int]()
+	subject := rx.NewSubject()
+	typed := FromObservable[int](subject).Subscribe(observer)
+	subject.Event <- rx.Event{Type: rx.EventTypeNext, Next: 1}
+	if event := <-observer.Event; event.Next != 1 {
+		t.Fatalf("Expected next value %v but got %v", 1, event.Next)
+	}
+
+	// the reader stops and closes Event, the untyped Observer is unsubscribed
+	typed.Unsubscribe(observer)
+	timeout := time.After(1 * time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-observer.Event:
+			closed = !ok
+			break
+		case <-timeout:
+			t.Fatalf("Expected Event closed after Unsubscribe")
+		}
+	}
+	select {
+	case <-subject.Finalize:
+		break
+	case <-time.After(1 * time.Second):
+		t.Fatalf("Expected upstream Finalize after its only observer unsubscribed")
+	}
+}